@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+const (
+	// configCommand groups the configuration subcommands.
+	configCommand = "config"
+	// configPrintCommand shows the effective configuration.
+	configPrintCommand = "print"
+)
+
+// runConfig runs a configuration subcommand and returns the process exit code.
+func runConfig(args []string, opts config.Options) int {
+	if len(args) != 1 || args[0] != configPrintCommand {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: %s [flags] %s %s\n", os.Args[0], configCommand, configPrintCommand)
+		return exitCodeUsage
+	}
+
+	effective, err := config.Resolve(opts)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = printEffectiveConfig(os.Stdout, effective); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// the settings are shown even when invalid, so the problem can be traced to its source
+	if _, err = effective.Load(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "\ninvalid configuration: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printEffectiveConfig writes the configuration file and a table of the settings with their sources.
+func printEffectiveConfig(w io.Writer, effective *config.Effective) error {
+	file := effective.File
+	if file == "" {
+		file = "(none)"
+	}
+	if _, err := fmt.Fprintf(w, "Config file: %s\n\n", file); err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd // column padding
+	_, _ = fmt.Fprintln(table, "KEY\tVALUE\tSOURCE")
+	for _, setting := range effective.Settings {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\n", setting.Key, setting.Value, setting.Source)
+	}
+	return table.Flush()
+}
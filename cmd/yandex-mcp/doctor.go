@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/tracker"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/wiki"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/ytoken"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/doctor"
+)
+
+// doctorCommand is the subcommand that verifies the setup end to end.
+const doctorCommand = "doctor"
+
+// runDoctor runs the setup checks against the configuration selected by opts and the subcommand arguments,
+// prints the report to stdout and returns the process exit code.
+func runDoctor(args []string, opts config.Options) int {
+	opts, ok := parseSubcommandFlags(doctorCommand, args, opts)
+	if !ok {
+		return exitCodeUsage
+	}
+
+	// Adapter logs would duplicate the report; failures are shown there with hints.
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	loadConfig := func() (*config.Config, error) { return config.LoadWithOptions(opts) }
+	report := doctor.New(loadConfig, newDoctorClientFactory()).Run(ctx)
+	if err := report.Print(os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// newDoctorClientFactory creates API clients for the checked profiles.
+// Profiles without own auth settings reuse the token provider of the primary profile, which is checked first.
+func newDoctorClientFactory() doctor.ClientFactory {
+	var primaryProvider *ytoken.ChainProvider
+
+	return func(profile *config.Config) (*doctor.OrgClients, error) {
+		httpClient, err := apihelpers.NewHTTPClient(profile)
+		if err != nil {
+			return nil, fmt.Errorf("build HTTP client: %w", err)
+		}
+
+		tokenProvider := primaryProvider
+		if tokenProvider == nil || !profile.InheritsAuth {
+			tokenProvider, err = ytoken.NewChainProvider(profile, nil)
+			if err != nil {
+				return nil, err
+			}
+			if primaryProvider == nil {
+				primaryProvider = tokenProvider
+			}
+		}
+
+		return &doctor.OrgClients{
+			TokenProvider: tokenProvider,
+			Tracker:       tracker.NewClient(profile, tokenProvider, httpClient, nil),
+			Wiki:          wiki.NewClient(profile, tokenProvider, httpClient, nil),
+		}, nil
+	}
+}
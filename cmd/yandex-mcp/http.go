@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/health"
+	"github.com/n-r-w/yandex-mcp/internal/server"
+)
+
+const (
+	// mcpPath is the URL path of the streamable HTTP endpoint.
+	mcpPath = "/mcp"
+	// livenessPath is the URL path reporting that the process is alive.
+	livenessPath = "/healthz"
+	// readinessPath is the URL path reporting whether the credentials and the APIs work.
+	readinessPath = "/readyz"
+	// httpReadHeaderTimeout bounds reading request headers of MCP clients.
+	httpReadHeaderTimeout = 10 * time.Second
+	// httpShutdownTimeout bounds completion of in-flight requests on exit.
+	httpShutdownTimeout = 5 * time.Second
+)
+
+// serveHTTP serves the MCP server over streamable HTTP until ctx is cancelled, accepting the client tokens
+// listed in cfg.ClientTokensFile. The health endpoints are served without client tokens for orchestrator probes.
+// The address is bound and the TLS certificate loaded before serving, so misconfiguration fails the startup.
+func serveHTTP(
+	ctx context.Context,
+	srv *server.Server,
+	cfg *config.Config,
+	checker *health.Checker,
+) error {
+	if cfg.ClientTokensFile == "" {
+		return errors.New("HTTP transport requires YANDEX_MCP_CLIENT_TOKENS_FILE")
+	}
+	clientTokens, err := loadClientTokens(cfg.ClientTokensFile)
+	if err != nil {
+		return err
+	}
+
+	handler, err := srv.HTTPHandler(server.HTTPOptions{
+		ClientTokens:      clientTokens,
+		CallerCredentials: cfg.CallerCredentials,
+	})
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(mcpPath, handler)
+	mux.Handle("GET "+livenessPath, health.LivenessHandler())
+	mux.Handle("GET "+readinessPath, checker.ReadinessHandler())
+
+	//nolint:exhaustruct // optional fields use defaults
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
+	if cfg.TLSCertFile != "" {
+		cert, certErr := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if certErr != nil {
+			return fmt.Errorf("load TLS certificate: %w", certErr)
+		}
+		httpServer.TLSConfig = &tls.Config{ //nolint:exhaustruct // optional fields use defaults
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", cfg.Listen) //nolint:exhaustruct // defaults
+	if err != nil {
+		return fmt.Errorf("listen for MCP clients on %q: %w", cfg.Listen, err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if httpServer.TLSConfig != nil {
+			serveErr <- httpServer.ServeTLS(listener, "", "")
+		} else {
+			serveErr <- httpServer.Serve(listener)
+		}
+	}()
+
+	slog.Info("starting MCP server over HTTP",
+		slog.String("address", listener.Addr().String()),
+		slog.String("path", mcpPath),
+		slog.Bool("tls", httpServer.TLSConfig != nil),
+		slog.Int("client_tokens", len(clientTokens)),
+		slog.String("caller_credentials", string(cfg.CallerCredentials)),
+	)
+
+	select {
+	case err = <-serveErr:
+		return fmt.Errorf("serve MCP clients: %w", err)
+	case <-ctx.Done():
+	}
+
+	// the run context is already cancelled, so in-flight requests get a fresh deadline
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err = httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("failed to stop MCP HTTP server", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// loadClientTokens reads the accepted client tokens, one per line. Empty lines and lines starting with # are skipped.
+func loadClientTokens(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read client tokens file: %w", err)
+	}
+
+	var tokens []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			tokens = append(tokens, line)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("client tokens file %s contains no tokens", path)
+	}
+	return tokens, nil
+}
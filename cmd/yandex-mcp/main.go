@@ -4,18 +4,22 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/n-r-w/yandex-mcp/internal/adapters/tracker"
-	"github.com/n-r-w/yandex-mcp/internal/adapters/wiki"
-	"github.com/n-r-w/yandex-mcp/internal/adapters/ytoken"
+	"github.com/n-r-w/yandex-mcp/internal/audit"
 	"github.com/n-r-w/yandex-mcp/internal/config"
-	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/n-r-w/yandex-mcp/internal/health"
+	"github.com/n-r-w/yandex-mcp/internal/metrics"
 	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/n-r-w/yandex-mcp/internal/telemetry"
+	diagnosticstools "github.com/n-r-w/yandex-mcp/internal/tools/diagnostics"
 	trackertools "github.com/n-r-w/yandex-mcp/internal/tools/tracker"
 	wikitools "github.com/n-r-w/yandex-mcp/internal/tools/wiki"
 )
@@ -30,6 +34,13 @@ var (
 	builtBy = "unknown"
 )
 
+const (
+	// exitCodeUsage is the conventional exit code for command line usage errors.
+	exitCodeUsage = 2
+	// tracingShutdownTimeout bounds flushing of pending spans on exit.
+	tracingShutdownTimeout = 5 * time.Second
+)
+
 // buildInfo holds build-time information.
 type buildInfo struct {
 	version string
@@ -50,8 +61,18 @@ func getBuildInfo() buildInfo {
 
 func main() {
 	showVersion := flag.Bool("version", false, "Show version information")
+	configFile := flag.String("config", "", "YAML or TOML configuration file (default: "+
+		"the first of config.yaml, config.yml, config.toml in the yandex-mcp user configuration directory)")
+	flag.String("metrics-listen", "", "Serve Prometheus metrics at /metrics on this address (e.g. :9464)")
+	flag.String("transport", "", "MCP transport: stdio or http (default stdio)")
+	flag.String("listen", "", "Serve MCP clients at /mcp on this address with --transport http")
+	flag.String("tls-cert", "", "PEM certificate file for serving --transport http over TLS")
+	flag.String("tls-key", "", "PEM private key file of --tls-cert")
+	flag.Usage = usage
 	flag.Parse()
 
+	opts := config.Options{File: *configFile, Flags: flagOverrides()}
+
 	info := getBuildInfo()
 
 	if *showVersion {
@@ -68,44 +89,150 @@ func main() {
 		os.Exit(0)
 	}
 
+	switch flag.Arg(0) {
+	case "":
+	case doctorCommand:
+		os.Exit(runDoctor(flag.Args()[1:], opts))
+	case configCommand:
+		os.Exit(runConfig(flag.Args()[1:], opts))
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "unknown command %q\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(exitCodeUsage)
+	}
+
 	//nolint:exhaustruct // SDK struct with optional fields
 	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
 	slog.SetDefault(logger)
 
-	if err := run(info.version); err != nil {
+	if err := run(info.version, opts); err != nil {
 		slog.Error("server failed", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
 }
 
-func run(serverVersion string) error {
+// usage prints the command line help.
+func usage() {
+	out := flag.CommandLine.Output()
+	_, _ = fmt.Fprintf(out, "Usage: %s [flags] [command]\n\nCommands:\n", os.Args[0])
+	_, _ = fmt.Fprintf(out, "  %s\tverify configuration, credentials and API access\n", doctorCommand)
+	_, _ = fmt.Fprintf(out, "  %s %s\tshow the effective configuration and where each value comes from\n\nFlags:\n",
+		configCommand, configPrintCommand)
+	flag.PrintDefaults()
+}
+
+// parseSubcommandFlags parses the flags given after a subcommand, which accepts --config like the server.
+// Positional arguments are rejected, so a misplaced flag is not silently ignored.
+// It returns false after printing the problem when the arguments are invalid.
+func parseSubcommandFlags(command string, args []string, opts config.Options) (config.Options, bool) {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	configFile := flags.String("config", opts.File, "YAML or TOML configuration file")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s [flags] %s [--config <path>]\n", os.Args[0], command)
+	}
+	if err := flags.Parse(args); err != nil {
+		return opts, false
+	}
+	if flags.NArg() > 0 {
+		_, _ = fmt.Fprintf(flags.Output(), "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return opts, false
+	}
+
+	opts.File = *configFile
+	return opts, true
+}
+
+// flagOverrides returns the configuration settings set on the command line, keyed by environment variable name.
+// Only explicitly set flags are returned, so unset flags do not hide the file and environment values.
+func flagOverrides() map[string]string {
+	envKeys := map[string]string{
+		"metrics-listen": "YANDEX_MCP_METRICS_LISTEN",
+		"transport":      "YANDEX_MCP_TRANSPORT",
+		"listen":         "YANDEX_MCP_LISTEN",
+		"tls-cert":       "YANDEX_MCP_TLS_CERT",
+		"tls-key":        "YANDEX_MCP_TLS_KEY",
+	}
+
+	overrides := make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		if key, ok := envKeys[f.Name]; ok {
+			overrides[key] = f.Value.String()
+		}
+	})
+	return overrides
+}
+
+func run(serverVersion string, opts config.Options) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	cfg, err := config.Load()
+	cfg, err := config.LoadWithOptions(opts)
 	if err != nil {
 		return err
 	}
 
 	slog.Info("configuration loaded",
-		slog.String("wiki_base_url", cfg.WikiBaseURL),
-		slog.String("tracker_base_url", cfg.TrackerBaseURL),
+		slog.String("config_file", cfg.ConfigFile),
+		slog.String("primary_org", cfg.OrgName),
+		slog.Int("org_profiles", len(cfg.OrgProfiles)+1),
+		slog.Bool("tracing", cfg.TracingEnabled),
+		slog.Bool("audit_log", cfg.AuditLogPath != ""),
 	)
+	if cfg.HTTPFixturesMode != config.FixturesModeOff {
+		slog.Warn("HTTP fixtures enabled",
+			slog.String("mode", string(cfg.HTTPFixturesMode)),
+			slog.String("dir", cfg.HTTPFixturesDir),
+		)
+	}
 
-	tokenProvider := ytoken.NewProvider(cfg)
+	shutdownTracing, err := telemetry.SetupTracing(ctx, cfg, serverVersion)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the run context is already cancelled on shutdown, so pending spans are flushed with a fresh deadline
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer flushCancel()
+		if shutdownErr := shutdownTracing(flushCtx); shutdownErr != nil {
+			slog.Warn("failed to flush traces", slog.String("error", shutdownErr.Error()))
+		}
+	}()
 
-	wikiClient := wiki.NewClient(cfg, tokenProvider)
-	trackerClient := tracker.NewClient(cfg, tokenProvider)
+	serverMetrics := metrics.New()
+	if cfg.MetricsListen != "" {
+		stopMetrics, serveErr := serveMetrics(ctx, cfg.MetricsListen, serverMetrics)
+		if serveErr != nil {
+			return serveErr
+		}
+		defer stopMetrics()
+	}
 
-	wikiTools := domain.WikiAllTools()
-	trackerTools := domain.TrackerAllTools()
+	auditSink, closeAuditLog, err := openAuditLog(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeAuditLog()
+
+	adapters, err := buildOrgAdapters(ctx, cfg, serverMetrics)
+	if err != nil {
+		return err
+	}
+
+	wikiTools := cfg.Tools.WikiTools()
+	trackerTools := cfg.Tools.TrackerTools()
+	slog.Info("tools selected",
+		slog.Int("tracker_tools", len(trackerTools)),
+		slog.Int("wiki_tools", len(wikiTools)),
+		slog.Bool("auth_diagnostics", cfg.Tools.AuthDiagnosticsEnabled()),
+	)
 
 	registrators := []server.IToolsRegistrator{
-		wikitools.NewRegistrator(wikiClient, wikiTools),
+		wikitools.NewRegistrator(adapters.wiki, wikiTools),
 		trackertools.NewRegistrator(
-			trackerClient,
+			adapters.tracker,
 			trackerTools,
 			cfg.AttachAllowedExtensions,
 			cfg.AttachViewExtensions,
@@ -113,13 +240,39 @@ func run(serverVersion string) error {
 		),
 	}
 
-	srv, err := server.New(serverVersion, registrators)
+	if cfg.Tools.AuthDiagnosticsEnabled() {
+		registrators = append(registrators, diagnosticstools.NewRegistrator(adapters.authProviders))
+	}
+
+	srv, err := server.New(serverVersion, registrators, serverMetrics, auditSink)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Transport == config.MCPTransportHTTP {
+		return serveHTTP(ctx, srv, cfg, health.NewChecker(adapters.healthTargets))
+	}
+
 	slog.Info("starting MCP server over stdio")
 
-	transport := &mcp.StdioTransport{}
-	return srv.Run(ctx, transport)
+	return srv.Run(ctx, &mcp.StdioTransport{})
+}
+
+// openAuditLog opens the audit log when it is configured and returns a function closing it.
+// A nil sink is returned when the audit log is disabled.
+func openAuditLog(cfg *config.Config) (server.IAuditSink, func(), error) {
+	if cfg.AuditLogPath == "" {
+		return nil, func() {}, nil
+	}
+
+	sink, err := audit.NewFileSink(cfg.AuditLogPath, cfg.AuditLogMaxBytes, cfg.AuditLogMaxBackups)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sink, func() {
+		if closeErr := sink.Close(); closeErr != nil {
+			slog.Warn("failed to close audit log", slog.String("error", closeErr.Error()))
+		}
+	}, nil
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/metrics"
+)
+
+const (
+	// metricsPath is the URL path of the Prometheus metrics endpoint.
+	metricsPath = "/metrics"
+	// metricsReadHeaderTimeout bounds reading request headers of metrics scrapes.
+	metricsReadHeaderTimeout = 10 * time.Second
+	// metricsShutdownTimeout bounds completion of in-flight scrapes on exit.
+	metricsShutdownTimeout = 5 * time.Second
+)
+
+// serveMetrics starts serving Prometheus metrics on addr and returns a function stopping the server.
+// The address is bound before returning, so a busy or invalid address fails the startup.
+func serveMetrics(ctx context.Context, addr string, m *metrics.Metrics) (func(), error) {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr) //nolint:exhaustruct // defaults
+	if err != nil {
+		return nil, fmt.Errorf("listen for metrics on %q: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, m.Handler())
+
+	//nolint:exhaustruct // optional fields use defaults
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	go func() {
+		if serveErr := srv.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			slog.Error("metrics server failed", slog.String("error", serveErr.Error()))
+		}
+	}()
+
+	slog.Info("serving metrics", slog.String("address", listener.Addr().String()), slog.String("path", metricsPath))
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+			slog.Warn("failed to stop metrics server", slog.String("error", shutdownErr.Error()))
+		}
+	}, nil
+}
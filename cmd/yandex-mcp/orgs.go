@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/tracker"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/wiki"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/ytoken"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/health"
+	"github.com/n-r-w/yandex-mcp/internal/metrics"
+	diagnosticstools "github.com/n-r-w/yandex-mcp/internal/tools/diagnostics"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
+	trackertools "github.com/n-r-w/yandex-mcp/internal/tools/tracker"
+	wikitools "github.com/n-r-w/yandex-mcp/internal/tools/wiki"
+)
+
+// orgAdapters holds per-organization-profile adapters routed by the tools' org argument.
+type orgAdapters struct {
+	wiki          *helpers.OrgRouter[wikitools.IWikiAdapter]
+	tracker       *helpers.OrgRouter[trackertools.ITrackerAdapter]
+	authProviders *helpers.OrgRouter[diagnosticstools.IAuthDiagnosticsProvider]
+	// healthTargets are probed by the readiness endpoint of the HTTP transport.
+	healthTargets []health.Target
+}
+
+// buildOrgAdapters creates API clients for the primary and additional organization profiles
+// and starts their token providers. Profiles without own auth settings share the primary token provider.
+func buildOrgAdapters(ctx context.Context, cfg *config.Config, m *metrics.Metrics) (*orgAdapters, error) {
+	primaryProvider, err := ytoken.NewChainProvider(cfg, m)
+	if err != nil {
+		return nil, err
+	}
+	primaryProvider.Start(ctx)
+
+	profiles := append([]*config.Config{cfg}, cfg.OrgProfiles...)
+	wikiAdapters := make(map[string]wikitools.IWikiAdapter, len(profiles))
+	trackerAdapters := make(map[string]trackertools.ITrackerAdapter, len(profiles))
+	authProviders := make(map[string]diagnosticstools.IAuthDiagnosticsProvider, len(profiles))
+	healthTargets := make([]health.Target, 0, len(profiles))
+
+	for _, profile := range profiles {
+		tokenProvider := primaryProvider
+		if profile != cfg && !profile.InheritsAuth {
+			tokenProvider, err = ytoken.NewChainProvider(profile, m)
+			if err != nil {
+				return nil, fmt.Errorf("org profile %q: %w", profile.OrgName, err)
+			}
+			tokenProvider.Start(ctx)
+		}
+
+		httpClient, err := apihelpers.NewHTTPClient(profile)
+		if err != nil {
+			return nil, fmt.Errorf("org profile %q: build HTTP client: %w", profile.OrgName, err)
+		}
+
+		wikiClient := wiki.NewClient(profile, tokenProvider, httpClient, m)
+		trackerClient := tracker.NewClient(profile, tokenProvider, httpClient, m)
+		wikiAdapters[profile.OrgName] = wikiClient
+		trackerAdapters[profile.OrgName] = trackerClient
+		authProviders[profile.OrgName] = tokenProvider
+		healthTarget := health.Target{
+			Org:           profile.OrgName,
+			TokenProvider: tokenProvider,
+			Tracker:       nil,
+			Wiki:          nil,
+		}
+		// services without enabled tools do not gate readiness
+		if len(cfg.Tools.TrackerTools()) > 0 {
+			healthTarget.Tracker = trackerClient
+		}
+		if len(cfg.Tools.WikiTools()) > 0 {
+			healthTarget.Wiki = wikiClient
+		}
+		healthTargets = append(healthTargets, healthTarget)
+
+		slog.Info("organization profile configured",
+			slog.String("org", profile.OrgName),
+			slog.String("org_type", string(profile.OrgType)),
+			slog.String("wiki_base_url", profile.WikiBaseURL),
+			slog.String("tracker_base_url", profile.TrackerBaseURL),
+			slog.String("auth_method", string(profile.AuthMethod)),
+			slog.Bool("shared_auth", profile != cfg && profile.InheritsAuth),
+		)
+	}
+
+	return &orgAdapters{
+		wiki:          helpers.NewOrgRouter(cfg.OrgName, wikiAdapters),
+		tracker:       helpers.NewOrgRouter(cfg.OrgName, trackerAdapters),
+		authProviders: helpers.NewOrgRouter(cfg.OrgName, authProviders),
+		healthTargets: healthTargets,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package apihelpers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/n-r-w/yandex-mcp/internal/audit"
+)
+
+// auditOperation adds an upstream operation to the audit record of the current tool call, if any.
+func (c *APIClient) auditOperation(ctx context.Context, method, endpointPath, operation, status string) {
+	path, _, _ := strings.Cut(endpointPath, "?")
+	audit.FromContext(ctx).AddOperation(audit.Operation{
+		Service:   c.serviceName,
+		Operation: operation,
+		Method:    method,
+		Path:      path,
+		Status:    status,
+	})
+}
@@ -0,0 +1,47 @@
+package apihelpers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/audit"
+)
+
+func TestAPIClient_AuditsUpstreamOperations(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"a"}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusNotFound, ""), nil),
+	)
+
+	record := audit.NewRecord(time.Now(), "tool", nil)
+	ctx := audit.NewContext(t.Context(), record)
+
+	_, err := client.DoGET(ctx, "/v1/cached/1?fields=all", nil, "GetCached")
+	require.NoError(t, err)
+	_, err = client.DoGET(ctx, "/v1/cached/1?fields=all", nil, "GetCached")
+	require.NoError(t, err)
+	_, err = client.DoGET(ctx, "/v1/other?q=secret", nil, "GetOther")
+	require.Error(t, err)
+
+	assert.Equal(t, []audit.Operation{
+		{Service: "test-service", Operation: "GetCached", Method: http.MethodGet, Path: "/v1/cached/1", Status: "200"},
+		{
+			Service:   "test-service",
+			Operation: "GetCached",
+			Method:    http.MethodGet,
+			Path:      "/v1/cached/1",
+			Status:    audit.StatusCached,
+		},
+		{Service: "test-service", Operation: "GetOther", Method: http.MethodGet, Path: "/v1/other", Status: "404"},
+	}, record.Operations)
+}
@@ -0,0 +1,155 @@
+package apihelpers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// CircuitBreakerConfigFromConfig returns the circuit breaker settings configured for the organization profile.
+func CircuitBreakerConfigFromConfig(cfg *config.Config) CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Threshold:   cfg.CircuitBreakerThreshold,
+		OpenTimeout: cfg.CircuitBreakerOpenTimeout,
+	}
+}
+
+// halfOpenRetryAfter is the retry hint for requests rejected while the half-open probe is in flight.
+// It is capped by the open timeout.
+const halfOpenRetryAfter = 5 * time.Second
+
+// circuitState is the state of a circuit breaker.
+type circuitState int
+
+const (
+	// circuitClosed lets all requests through and counts consecutive failures.
+	circuitClosed circuitState = iota
+	// circuitOpen rejects requests until the open timeout elapses.
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through to check whether the service recovered.
+	circuitHalfOpen
+)
+
+// requestOutcome classifies a finished request for the circuit breaker.
+type requestOutcome int
+
+const (
+	// outcomeSuccess means the service answered; client errors such as 404 also prove it is up.
+	outcomeSuccess requestOutcome = iota
+	// outcomeFailure means the service is unreachable or failing (network errors, 5xx).
+	outcomeFailure
+	// outcomeNeutral means the request says nothing about the service health (token errors, caller cancellation).
+	outcomeNeutral
+)
+
+// circuitBreaker stops sending requests to a service after consecutive failures
+// and periodically probes it to detect recovery.
+type circuitBreaker struct {
+	service     domain.Service
+	threshold   int
+	openTimeout time.Duration
+	nowFunc     func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newCircuitBreaker creates a circuit breaker, or returns nil when threshold is not positive.
+func newCircuitBreaker(service domain.Service, threshold int, openTimeout time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		service:     service,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		nowFunc:     time.Now,
+		mu:          sync.Mutex{},
+		state:       circuitClosed,
+		failures:    0,
+		openedAt:    time.Time{},
+		probing:     false,
+	}
+}
+
+// allow reports whether a request may be sent. When the circuit is open, or a probe
+// is already in flight, it returns domain.ServiceUnavailableError.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		elapsed := b.nowFunc().Sub(b.openedAt)
+		if elapsed < b.openTimeout {
+			return domain.ServiceUnavailableError{Service: b.service, RetryAfter: b.openTimeout - elapsed}
+		}
+		b.state = circuitHalfOpen
+	}
+
+	if b.state == circuitHalfOpen {
+		if b.probing {
+			return domain.ServiceUnavailableError{Service: b.service, RetryAfter: min(halfOpenRetryAfter, b.openTimeout)}
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record updates the breaker state with the outcome of an allowed request.
+func (b *circuitBreaker) record(ctx context.Context, outcome requestOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == circuitHalfOpen
+	if wasProbe {
+		b.probing = false
+	}
+
+	switch outcome {
+	case outcomeNeutral:
+		return
+	case outcomeSuccess:
+		if wasProbe {
+			slog.InfoContext(ctx, "circuit breaker closed", slog.String("service", string(b.service)))
+		}
+		b.state = circuitClosed
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if wasProbe || (b.state == circuitClosed && b.failures >= b.threshold) {
+			b.state = circuitOpen
+			b.openedAt = b.nowFunc()
+			slog.WarnContext(ctx, "circuit breaker opened",
+				slog.String("service", string(b.service)),
+				slog.Int("consecutive_failures", b.failures),
+				slog.Duration("open_timeout", b.openTimeout),
+			)
+		}
+	}
+}
+
+// classifyOutcome decides how a finished request affects the circuit breaker.
+func classifyOutcome(ctx context.Context, resp *http.Response, err error) requestOutcome {
+	if err != nil {
+		var tErr *transportError
+		if !errors.As(err, &tErr) || ctx.Err() != nil || errors.Is(err, errFixtureNotFound) {
+			return outcomeNeutral
+		}
+		return outcomeFailure
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
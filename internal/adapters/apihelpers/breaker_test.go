@@ -0,0 +1,135 @@
+package apihelpers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// newTestCircuitBreaker creates a breaker with a controllable clock.
+func newTestCircuitBreaker(threshold int, openTimeout time.Duration) (*circuitBreaker, *time.Time) {
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	breaker := newCircuitBreaker(domain.ServiceTracker, threshold, openTimeout)
+	breaker.nowFunc = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestNewCircuitBreaker_DisabledWithoutThreshold(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newCircuitBreaker(domain.ServiceWiki, 0, time.Minute))
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+
+	breaker, _ := newTestCircuitBreaker(3, time.Minute)
+
+	for range 2 {
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+	}
+	require.NoError(t, breaker.allow())
+	breaker.record(t.Context(), outcomeSuccess)
+
+	for range 3 {
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+	}
+
+	err := breaker.allow()
+	var unavailableErr domain.ServiceUnavailableError
+	require.ErrorAs(t, err, &unavailableErr)
+	assert.Equal(t, domain.ServiceTracker, unavailableErr.Service)
+	assert.Equal(t, time.Minute, unavailableErr.RetryAfter)
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful probe closes the circuit", func(t *testing.T) {
+		t.Parallel()
+		breaker, now := newTestCircuitBreaker(1, time.Minute)
+
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+		require.Error(t, breaker.allow())
+
+		*now = now.Add(time.Minute)
+		require.NoError(t, breaker.allow())
+		err := breaker.allow()
+		var unavailableErr domain.ServiceUnavailableError
+		require.ErrorAs(t, err, &unavailableErr, "only one probe may be in flight")
+		assert.Equal(t, halfOpenRetryAfter, unavailableErr.RetryAfter)
+
+		breaker.record(t.Context(), outcomeSuccess)
+		require.NoError(t, breaker.allow())
+		require.NoError(t, breaker.allow())
+	})
+
+	t.Run("failed probe reopens the circuit", func(t *testing.T) {
+		t.Parallel()
+		breaker, now := newTestCircuitBreaker(1, time.Minute)
+
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+
+		*now = now.Add(time.Minute)
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+
+		require.Error(t, breaker.allow())
+	})
+
+	t.Run("neutral probe lets the next request probe", func(t *testing.T) {
+		t.Parallel()
+		breaker, now := newTestCircuitBreaker(1, time.Minute)
+
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeFailure)
+
+		*now = now.Add(time.Minute)
+		require.NoError(t, breaker.allow())
+		breaker.record(t.Context(), outcomeNeutral)
+
+		require.NoError(t, breaker.allow())
+	})
+}
+
+func TestClassifyOutcome(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	assert.Equal(t, outcomeSuccess, classifyOutcome(ctx, newStatusResponse(http.StatusOK, ""), nil))
+	assert.Equal(t, outcomeSuccess, classifyOutcome(ctx, newStatusResponse(http.StatusNotFound, ""), nil))
+	assert.Equal(t, outcomeFailure, classifyOutcome(ctx, newStatusResponse(http.StatusBadGateway, ""), nil))
+	assert.Equal(t, outcomeFailure, classifyOutcome(ctx, nil, &transportError{err: errors.New("connection refused")}))
+	assert.Equal(t, outcomeNeutral, classifyOutcome(ctx, nil, errors.New("get token: failed")))
+}
+
+func TestDoGET_FailsFastWhenCircuitIsOpen(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client := newTestAPIClient(doer, provider)
+	client.breaker = newCircuitBreaker(domain.ServiceTracker, 2, time.Minute)
+
+	doer.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused")).Times(2)
+
+	for range 2 {
+		_, err := client.DoGET(t.Context(), "/v1/resource", nil, "operation")
+		require.Error(t, err)
+	}
+
+	_, err := client.DoGET(t.Context(), "/v1/resource", nil, "operation")
+	var unavailableErr domain.ServiceUnavailableError
+	require.ErrorAs(t, err, &unavailableErr)
+}
@@ -0,0 +1,321 @@
+package apihelpers
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/singleflight/v2"
+	"github.com/n-r-w/yandex-mcp/internal/audit"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+const (
+	// maxCachedBodyBytes bounds the size of a single cached response body.
+	maxCachedBodyBytes = 1 << 20
+	// sharedFetchTimeout bounds an upstream call shared by concurrent requests, including its retries,
+	// since it is not cancelled when the request that started it is.
+	sharedFetchTimeout = 2 * time.Minute
+)
+
+// CacheRule sets how long GET responses of endpoints under PathPrefix are served from cache.
+type CacheRule struct {
+	// PathPrefix matches the endpoint path (without query) exactly or as a parent path.
+	PathPrefix string
+	TTL        time.Duration
+}
+
+// CacheConfig configures the GET response cache.
+type CacheConfig struct {
+	// MaxEntries bounds the number of cached responses; zero disables the cache.
+	MaxEntries int
+	// Rules lists cacheable endpoints; the first matching rule wins and other endpoints are not cached.
+	Rules []CacheRule
+}
+
+// CacheConfigFromConfig returns the cache settings configured for the organization profile with the adapter's rules.
+func CacheConfigFromConfig(cfg *config.Config, rules []CacheRule) CacheConfig {
+	maxEntries := 0
+	if cfg.HTTPCacheEnabled {
+		maxEntries = cfg.HTTPCacheMaxEntries
+	}
+	return CacheConfig{
+		MaxEntries: maxEntries,
+		Rules:      rules,
+	}
+}
+
+// cachedResponse is an immutable cached GET response.
+type cachedResponse struct {
+	key       string
+	path      string // endpoint path, shared by the entries of all callers
+	header    http.Header
+	body      []byte
+	etag      string
+	expiresAt time.Time
+}
+
+// responseCache is an LRU cache of GET responses with per-endpoint TTLs.
+type responseCache struct {
+	rules      []CacheRule
+	maxEntries int
+	nowFunc    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used at the front
+	// invalidations counts invalidations, so a fetch started before one does not store its outdated response
+	invalidations uint64
+
+	// single-flight group for concurrent requests with the same cache key
+	sf singleflight.Group[string, *cachedResponse]
+}
+
+// newResponseCache creates a response cache, or returns nil when caching is disabled.
+func newResponseCache(cfg CacheConfig) *responseCache {
+	if cfg.MaxEntries <= 0 || len(cfg.Rules) == 0 {
+		return nil
+	}
+	return &responseCache{
+		rules:         cfg.Rules,
+		maxEntries:    cfg.MaxEntries,
+		nowFunc:       time.Now,
+		mu:            sync.Mutex{},
+		entries:       make(map[string]*list.Element),
+		order:         list.New(),
+		invalidations: 0,
+		sf:            singleflight.Group[string, *cachedResponse]{},
+	}
+}
+
+// ttl returns the cache TTL for the endpoint, or zero if it is not cacheable.
+func (rc *responseCache) ttl(endpointPath string) time.Duration {
+	if rc == nil {
+		return 0
+	}
+
+	rule, ok := rc.matchRule(endpointPath)
+	if !ok {
+		return 0
+	}
+	return rule.TTL
+}
+
+// matchRule returns the first cache rule matching the endpoint path.
+func (rc *responseCache) matchRule(endpointPath string) (CacheRule, bool) {
+	for _, rule := range rc.rules {
+		if hasPathPrefix(endpointPath, rule.PathPrefix) {
+			return rule, true
+		}
+	}
+	return CacheRule{}, false //nolint:exhaustruct // zero value is unused when not found
+}
+
+// invalidate drops cached responses of all endpoints covered by the rule matching the modified endpoint,
+// since a change of one resource may affect listings and related resources under the same prefix.
+func (rc *responseCache) invalidate(endpointPath string) {
+	if rc == nil {
+		return
+	}
+
+	rule, ok := rc.matchRule(endpointPath)
+	if !ok {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.invalidations++
+	for key, elem := range rc.entries {
+		entry := elem.Value.(*cachedResponse) //nolint:errcheck,forcetypeassert // see get
+		if hasPathPrefix(entry.path, rule.PathPrefix) {
+			rc.order.Remove(elem)
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// hasPathPrefix reports whether the endpoint path (without query) equals prefix or lies under it.
+func hasPathPrefix(endpointPath, prefix string) bool {
+	path, _, _ := strings.Cut(endpointPath, "?")
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// get returns the cached response for the key, including expired ones, and marks it as recently used.
+func (rc *responseCache) get(key string) *cachedResponse {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	elem, ok := rc.entries[key]
+	if !ok {
+		return nil
+	}
+	rc.order.MoveToFront(elem)
+	return elem.Value.(*cachedResponse) //nolint:errcheck,forcetypeassert // the list holds only cached responses
+}
+
+// invalidationCount returns the number of invalidations so far, to be passed to putUnlessInvalidated.
+func (rc *responseCache) invalidationCount() uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.invalidations
+}
+
+// putUnlessInvalidated stores a fetched response unless the cache was invalidated since the fetch started,
+// when the response may predate a change made through the server.
+func (rc *responseCache) putUnlessInvalidated(entry *cachedResponse, invalidations uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.invalidations == invalidations {
+		rc.putLocked(entry)
+	}
+}
+
+// put stores the response, evicting the least recently used entries over the limit.
+func (rc *responseCache) put(entry *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.putLocked(entry)
+}
+
+// putLocked is put with rc.mu held.
+func (rc *responseCache) putLocked(entry *cachedResponse) {
+	if elem, ok := rc.entries[entry.key]; ok {
+		elem.Value = entry
+		rc.order.MoveToFront(elem)
+		return
+	}
+
+	rc.entries[entry.key] = rc.order.PushFront(entry)
+	for rc.order.Len() > rc.maxEntries {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cachedResponse).key) //nolint:errcheck,forcetypeassert // see get
+	}
+}
+
+// doCachedGET serves a GET request from the response cache. Expired entries with an ETag are revalidated
+// with If-None-Match; concurrent requests of the same endpoint and caller share a single upstream call.
+// The shared call outlives the cancellation of the request that started it, while every request stops
+// waiting for it when its own context is done.
+// Cache hits are counted per request, misses and revalidations once per upstream call.
+func (c *APIClient) doCachedGET(
+	ctx context.Context,
+	endpointPath string,
+	ttl time.Duration,
+	result any,
+	operation string,
+) (http.Header, error) {
+	key := cacheKey(ctx, endpointPath)
+	entry := c.cache.get(key)
+	if entry != nil && c.cache.nowFunc().Before(entry.expiresAt) {
+		c.observeCacheLookup(cacheResultHit)
+		c.auditOperation(ctx, http.MethodGet, endpointPath, operation, audit.StatusCached)
+	} else {
+		// written by the shared call before its result is sent, so it is read only after receiving the result
+		fetched := false
+		results := c.cache.sf.DoChan(context.WithoutCancel(ctx), key,
+			func(ctx context.Context) (*cachedResponse, error) {
+				fetched = true
+				ctx, cancel := context.WithTimeout(ctx, sharedFetchTimeout)
+				defer cancel()
+				return c.fetchCachedGET(ctx, key, endpointPath, ttl, operation)
+			})
+
+		select {
+		case <-ctx.Done():
+			return nil, c.ErrorLogWrapper(ctx, ctx.Err())
+		case result := <-results:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			entry = result.Val
+		}
+		if !fetched {
+			// the response was fetched by a concurrent request and is audited as served from cache
+			c.auditOperation(ctx, http.MethodGet, endpointPath, operation, audit.StatusCached)
+		}
+	}
+
+	if result != nil && len(entry.body) > 0 {
+		if err := json.Unmarshal(entry.body, result); err != nil {
+			return nil, c.ErrorLogWrapper(ctx, fmt.Errorf("decode response: %w", err))
+		}
+	}
+
+	return entry.header.Clone(), nil
+}
+
+// fetchCachedGET fetches or revalidates a cacheable GET response and stores it in the cache under the key.
+func (c *APIClient) fetchCachedGET(
+	ctx context.Context,
+	key, endpointPath string,
+	ttl time.Duration,
+	operation string,
+) (*cachedResponse, error) {
+	invalidations := c.cache.invalidationCount()
+	stale := c.cache.get(key)
+
+	var header http.Header
+	if stale != nil && stale.etag != "" {
+		header = http.Header{HeaderIfNoneMatch: []string{stale.etag}}
+	}
+
+	resp, err := c.executeRequest(ctx, http.MethodGet, endpointPath, nil, header, operation)
+	if err != nil {
+		c.observeCacheLookup(cacheResultMiss)
+		return nil, c.ErrorLogWrapper(ctx, err)
+	}
+
+	defer func() {
+		if err = resp.Body.Close(); err != nil {
+			slog.WarnContext(ctx, "failed to close response body", "error", err)
+		}
+	}()
+
+	expiresAt := c.cache.nowFunc().Add(ttl)
+
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
+		c.observeCacheLookup(cacheResultRevalidated)
+		renewed := *stale
+		renewed.expiresAt = expiresAt
+		c.cache.putUnlessInvalidated(&renewed, invalidations)
+		return &renewed, nil
+	}
+
+	c.observeCacheLookup(cacheResultMiss)
+
+	bodyBytes, err := c.readJSONBody(resp.Body, operation)
+	if err != nil {
+		return nil, c.ErrorLogWrapper(ctx, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, c.parseHTTPError(ctx, resp.StatusCode, bodyBytes, operation)
+	}
+
+	entry := &cachedResponse{
+		key:       key,
+		path:      endpointPath,
+		header:    resp.Header.Clone(),
+		body:      bodyBytes,
+		etag:      resp.Header.Get(HeaderETag),
+		expiresAt: expiresAt,
+	}
+	if len(bodyBytes) <= maxCachedBodyBytes {
+		c.cache.putUnlessInvalidated(entry, invalidations)
+	}
+
+	return entry, nil
+}
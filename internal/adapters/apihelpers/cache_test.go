@@ -0,0 +1,298 @@
+package apihelpers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newCacheTestClient creates an API client that caches /v1/cached responses and has a controllable clock.
+func newCacheTestClient(doer IHTTPDoer, provider ITokenProvider) (*APIClient, *time.Time) {
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	client := newTestAPIClient(doer, provider)
+	client.cache = newResponseCache(CacheConfig{
+		MaxEntries: 2,
+		Rules:      []CacheRule{{PathPrefix: "/v1/cached", TTL: time.Minute}},
+	})
+	client.cache.nowFunc = func() time.Time { return now }
+	return client, &now
+}
+
+// newBodyResponse creates a response with the given status, body and optional ETag header.
+func newBodyResponse(statusCode int, body, etag string) *http.Response {
+	header := make(http.Header)
+	if etag != "" {
+		header.Set(HeaderETag, etag)
+	}
+	return &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Header:     header,
+	}
+}
+
+type cachedValue struct {
+	Value string `json:"value"`
+}
+
+func TestNewResponseCache_Disabled(t *testing.T) {
+	t.Parallel()
+
+	rules := []CacheRule{{PathPrefix: "/v1/cached", TTL: time.Minute}}
+
+	assert.Nil(t, newResponseCache(CacheConfig{MaxEntries: 0, Rules: rules}))
+	assert.Nil(t, newResponseCache(CacheConfig{MaxEntries: 10, Rules: nil}))
+}
+
+func TestResponseCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	cache := newResponseCache(CacheConfig{
+		MaxEntries: 10,
+		Rules: []CacheRule{
+			{PathPrefix: "/v3/queues/", TTL: time.Minute},
+			{PathPrefix: "/v3/myself", TTL: time.Hour},
+		},
+	})
+
+	testCases := []struct {
+		name         string
+		endpointPath string
+		want         time.Duration
+	}{
+		{name: "exact path", endpointPath: "/v3/myself", want: time.Hour},
+		{name: "trailing slash prefix", endpointPath: "/v3/queues/", want: time.Minute},
+		{name: "nested path with query", endpointPath: "/v3/queues/TEST?expand=all", want: time.Minute},
+		{name: "sibling path", endpointPath: "/v3/myselfish", want: 0},
+		{name: "uncached path", endpointPath: "/v3/issues/TEST-1", want: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.want, cache.ttl(testCase.endpointPath))
+		})
+	}
+
+	var disabled *responseCache
+	assert.Zero(t, disabled.ttl("/v3/myself"))
+}
+
+func TestResponseCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache := newResponseCache(CacheConfig{
+		MaxEntries: 2,
+		Rules:      []CacheRule{{PathPrefix: "/v1", TTL: time.Minute}},
+	})
+
+	cache.put(&cachedResponse{key: "a"}) //nolint:exhaustruct // only the key matters for eviction
+	cache.put(&cachedResponse{key: "b"}) //nolint:exhaustruct // see above
+	require.NotNil(t, cache.get("a"))
+	cache.put(&cachedResponse{key: "c"}) //nolint:exhaustruct // see above
+
+	assert.NotNil(t, cache.get("a"))
+	assert.Nil(t, cache.get("b"))
+	assert.NotNil(t, cache.get("c"))
+}
+
+func TestDoGET_ServesFreshResponsesFromCache(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"first"}`, ""), nil).Times(1)
+
+	for range 2 {
+		var result cachedValue
+		_, err := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+		require.NoError(t, err)
+		assert.Equal(t, "first", result.Value)
+	}
+}
+
+func TestDoGET_RevalidatesExpiredResponses(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, now := newCacheTestClient(doer, provider)
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"first"}`, `"v1"`), nil),
+		doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, `"v1"`, req.Header.Get(HeaderIfNoneMatch))
+			return newBodyResponse(http.StatusNotModified, "", ""), nil
+		}),
+	)
+
+	var result cachedValue
+	_, err := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+
+	*now = now.Add(2 * time.Minute)
+
+	result = cachedValue{Value: ""}
+	headers, err := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "first", result.Value)
+	assert.Equal(t, `"v1"`, headers.Get(HeaderETag))
+
+	// the revalidated entry is fresh again
+	_, err = client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+}
+
+func TestDoGET_SharedRequestSurvivesLeaderCancellation(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-release
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return newBodyResponse(http.StatusOK, `{"value":"shared"}`, ""), nil
+	}).Times(1)
+
+	leaderCtx, cancelLeader := context.WithCancel(t.Context())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.DoGET(leaderCtx, "/v1/cached/item", nil, "operation")
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan cachedValue, 1)
+	followerErr := make(chan error, 1)
+	go func() {
+		var result cachedValue
+		_, err := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+		followerErr <- err
+		followerResult <- result
+	}()
+
+	cancelLeader()
+	require.ErrorIs(t, <-leaderErr, context.Canceled, "the leader stops waiting when cancelled")
+
+	close(release)
+	require.NoError(t, <-followerErr)
+	assert.Equal(t, "shared", (<-followerResult).Value)
+}
+
+func TestDoGET_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusNotFound, `{}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"found"}`, ""), nil),
+	)
+
+	var result cachedValue
+	_, err := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+
+	_, err = client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "found", result.Value)
+}
+
+func TestDoRequest_InvalidatesCachedResponses(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"old"}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"new"}`, ""), nil),
+	)
+
+	var result cachedValue
+	_, err := client.DoGET(t.Context(), "/v1/cached", &result, "operation")
+	require.NoError(t, err)
+
+	_, err = client.DoPATCH(t.Context(), "/v1/cached/item", map[string]string{"value": "new"}, nil, "operation")
+	require.NoError(t, err)
+
+	_, err = client.DoGET(t.Context(), "/v1/cached", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "new", result.Value)
+}
+
+func TestDoRequest_ReadOnlySearchKeepsCachedResponses(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"cached"}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `[]`, ""), nil),
+	)
+
+	var result cachedValue
+	_, err := client.DoGET(t.Context(), "/v1/cached", &result, "operation")
+	require.NoError(t, err)
+
+	_, err = client.DoPOST(t.Context(), "/v1/cached/_search", map[string]string{"filter": "all"}, nil, "operation")
+	require.NoError(t, err)
+
+	result = cachedValue{Value: ""}
+	_, err = client.DoGET(t.Context(), "/v1/cached", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "cached", result.Value)
+}
+
+func TestDoGET_DoesNotStoreResponseFetchedBeforeInvalidation(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+			close(started)
+			<-release
+			return newBodyResponse(http.StatusOK, `{"value":"old"}`, ""), nil
+		}),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{}`, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"new"}`, ""), nil),
+	)
+
+	fetched := make(chan error, 1)
+	go func() {
+		_, err := client.DoGET(t.Context(), "/v1/cached/item", nil, "operation")
+		fetched <- err
+	}()
+	<-started
+
+	_, err := client.DoPATCH(t.Context(), "/v1/cached/item", map[string]string{"value": "new"}, nil, "operation")
+	require.NoError(t, err)
+	close(release)
+	require.NoError(t, <-fetched)
+
+	var result cachedValue
+	_, err = client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "new", result.Value, "the response fetched before the change must not be cached")
+}
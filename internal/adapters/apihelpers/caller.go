@@ -0,0 +1,40 @@
+package apihelpers
+
+import (
+	"context"
+
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+)
+
+// callerTokenProvider supplies the token the MCP client passed with the current tool call.
+// The token belongs to the caller, so it is never refreshed.
+type callerTokenProvider struct {
+	credentials callerauth.Credentials
+}
+
+// Token implements ITokenProvider.
+func (p callerTokenProvider) Token(context.Context, bool) (string, error) {
+	return p.credentials.Token, nil
+}
+
+// AuthScheme implements ITokenProvider.
+func (p callerTokenProvider) AuthScheme() string {
+	return p.credentials.Scheme
+}
+
+// tokenProviderFor returns the caller's credentials when the tool call carries them, or the server's token provider.
+func (c *APIClient) tokenProviderFor(ctx context.Context) ITokenProvider {
+	if credentials, ok := callerauth.FromContext(ctx); ok {
+		return callerTokenProvider{credentials: credentials}
+	}
+	return c.tokenProvider
+}
+
+// cacheKey returns the response cache key of the endpoint. Responses fetched with caller credentials
+// are cached per caller, since callers may be allowed to see different data.
+func cacheKey(ctx context.Context, endpointPath string) string {
+	if credentials, ok := callerauth.FromContext(ctx); ok {
+		return credentials.ID() + " " + endpointPath
+	}
+	return endpointPath
+}
@@ -0,0 +1,72 @@
+package apihelpers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+)
+
+func TestDoRequest_UsesCallerCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	doer := NewMockIHTTPDoer(ctrl)
+	// the server's token provider must not be consulted for calls with caller credentials
+	client := newTestAPIClient(doer, NewMockITokenProvider(ctrl))
+
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "OAuth caller-token", req.Header.Get(HeaderAuthorization))
+		return newBodyResponse(http.StatusForbidden, `{}`, ""), nil
+	}).Times(1)
+
+	ctx := callerauth.NewContext(t.Context(),
+		callerauth.Credentials{Scheme: callerauth.SchemeOAuth, Token: "caller-token"})
+	_, err := client.DoGET(ctx, "/v1/resource", nil, "GetResource")
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr, "a rejected caller token must not be refreshed and retried")
+	assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+}
+
+func TestDoGET_CachesResponsesPerCaller(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client, _ := newCacheTestClient(doer, provider)
+	client.cache.maxEntries = 10
+
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return newBodyResponse(http.StatusOK, `{"value":"`+req.Header.Get(HeaderAuthorization)+`"}`, ""), nil
+	}).Times(5)
+
+	alice := callerauth.NewContext(t.Context(), callerauth.Credentials{Scheme: callerauth.SchemeOAuth, Token: "alice"})
+	bob := callerauth.NewContext(t.Context(), callerauth.Credentials{Scheme: callerauth.SchemeOAuth, Token: "bob"})
+
+	var result cachedValue
+	for range 2 {
+		_, err := client.DoGET(alice, "/v1/cached/item", &result, "operation")
+		require.NoError(t, err)
+		assert.Equal(t, "OAuth alice", result.Value)
+
+		_, err = client.DoGET(bob, "/v1/cached/item", &result, "operation")
+		require.NoError(t, err)
+		assert.Equal(t, "OAuth bob", result.Value)
+
+		_, err = client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", result.Value)
+	}
+
+	// a change made by one caller invalidates the responses cached for everyone
+	_, err := client.DoPATCH(alice, "/v1/cached/item", map[string]string{"value": "new"}, nil, "operation")
+	require.NoError(t, err)
+
+	_, err = client.DoGET(bob, "/v1/cached/item", &result, "operation")
+	require.NoError(t, err)
+	assert.Equal(t, "OAuth bob", result.Value)
+}
@@ -8,12 +8,22 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/n-r-w/yandex-mcp/internal/cachecontrol"
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+	"github.com/n-r-w/yandex-mcp/internal/config"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // ErrorParseFunc is a function that parses an HTTP error into a domain error.
@@ -26,10 +36,18 @@ type APIClient struct {
 	baseURL             *url.URL
 	baseURLParseErr     error
 	orgID               string
+	orgIDHeader         string
 	extraHeaders        map[string]string
 	serviceName         string
 	parseError          ErrorParseFunc
 	rawResponseMaxBytes int64
+	maxResponseBytes    int64 // zero disables the limit
+	retry               RetryPolicy
+	limiter             *rate.Limiter   // nil disables rate limiting
+	breaker             *circuitBreaker // nil disables the circuit breaker
+	cache               *responseCache  // nil disables the response cache
+	tracer              trace.Tracer
+	metrics             IMetrics // nil disables metrics
 }
 
 // APIClientConfig contains configuration for creating an APIClient.
@@ -38,11 +56,27 @@ type APIClientConfig struct {
 	TokenProvider       ITokenProvider
 	BaseURL             string
 	OrgID               string
+	OrgIDHeader         string // defaults to HeaderCloudOrgID
 	ExtraHeaders        map[string]string
 	ServiceName         string
 	ParseError          ErrorParseFunc
 	HTTPTimeout         time.Duration
 	RawResponseMaxBytes int64
+	MaxResponseBytes    int64 // limit of JSON response bodies; zero disables the limit
+	Retry               RetryPolicy
+	RateLimit           float64 // requests per second; zero disables rate limiting
+	RateBurst           int
+	CircuitBreaker      CircuitBreakerConfig
+	Cache               CacheConfig
+	Metrics             IMetrics // nil disables metrics
+}
+
+// CircuitBreakerConfig configures the per-service circuit breaker.
+type CircuitBreakerConfig struct {
+	// Threshold is the number of consecutive failed requests that opens the circuit; zero disables the breaker.
+	Threshold int
+	// OpenTimeout is how long the circuit stays open before a probe request is let through.
+	OpenTimeout time.Duration
 }
 
 // NewAPIClient creates a new APIClient with the given configuration.
@@ -58,6 +92,11 @@ func NewAPIClient(cfg APIClientConfig) *APIClient {
 		}
 	}
 
+	orgIDHeader := cfg.OrgIDHeader
+	if orgIDHeader == "" {
+		orgIDHeader = HeaderCloudOrgID
+	}
+
 	parsedBaseURL, baseURLParseErr := parseBaseURL(cfg.BaseURL)
 
 	return &APIClient{
@@ -66,11 +105,37 @@ func NewAPIClient(cfg APIClientConfig) *APIClient {
 		baseURL:             parsedBaseURL,
 		baseURLParseErr:     baseURLParseErr,
 		orgID:               cfg.OrgID,
+		orgIDHeader:         orgIDHeader,
 		extraHeaders:        cfg.ExtraHeaders,
 		serviceName:         cfg.ServiceName,
 		parseError:          cfg.ParseError,
 		rawResponseMaxBytes: cfg.RawResponseMaxBytes,
+		maxResponseBytes:    cfg.MaxResponseBytes,
+		retry:               cfg.Retry,
+		limiter:             newRateLimiter(cfg.RateLimit, cfg.RateBurst),
+		breaker: newCircuitBreaker(
+			domain.Service(cfg.ServiceName), cfg.CircuitBreaker.Threshold, cfg.CircuitBreaker.OpenTimeout),
+		cache:   newResponseCache(cfg.Cache),
+		tracer:  otel.Tracer(tracerName),
+		metrics: cfg.Metrics,
+	}
+}
+
+// newRateLimiter creates a token-bucket limiter shared by all requests of the client,
+// or returns nil when rate limiting is disabled.
+func newRateLimiter(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), max(burst, 1))
+}
+
+// OrgIDHeader returns the organization ID header name for the given organization type.
+func OrgIDHeader(orgType config.OrgType) string {
+	if orgType == config.OrgType360 {
+		return HeaderOrgID
 	}
+	return HeaderCloudOrgID
 }
 
 // DoRequest performs an HTTP request and returns response headers.
@@ -81,7 +146,7 @@ func (c *APIClient) DoRequest(
 	result any,
 	operation string,
 ) (http.Header, error) {
-	resp, err := c.executeRequestWithAuthRetry(ctx, method, endpointPath, body)
+	resp, err := c.executeRequest(ctx, method, endpointPath, body, nil, operation)
 	if err != nil {
 		return nil, c.ErrorLogWrapper(ctx, err)
 	}
@@ -102,7 +167,7 @@ func (c *APIClient) DoRequestRaw(
 	body any,
 	operation string,
 ) (http.Header, []byte, error) {
-	resp, err := c.executeRequestWithAuthRetry(ctx, method, endpointPath, body)
+	resp, err := c.executeRequest(ctx, method, endpointPath, body, nil, operation)
 	if err != nil {
 		return nil, nil, c.ErrorLogWrapper(ctx, err)
 	}
@@ -133,7 +198,7 @@ func (c *APIClient) DoRequestStream(
 	body any,
 	operation string,
 ) (http.Header, io.ReadCloser, error) {
-	resp, err := c.executeRequestWithAuthRetry(ctx, method, endpointPath, body)
+	resp, err := c.executeRequest(ctx, method, endpointPath, body, nil, operation)
 	if err != nil {
 		return nil, nil, c.ErrorLogWrapper(ctx, err)
 	}
@@ -169,7 +234,12 @@ func readResponseBody(reader io.Reader, maxBytes int64) ([]byte, error) {
 }
 
 // DoGET executes a GET request with token injection.
+// Responses of endpoints matching the cache rules are served from the response cache,
+// unless the context asks to bypass it (see cachecontrol).
 func (c *APIClient) DoGET(ctx context.Context, endpointPath string, result any, operation string) (http.Header, error) {
+	if ttl := c.cache.ttl(endpointPath); ttl > 0 && !cachecontrol.Bypassed(ctx) {
+		return c.doCachedGET(ctx, endpointPath, ttl, result, operation)
+	}
 	return c.DoRequest(ctx, http.MethodGet, endpointPath, nil, result, operation)
 }
 
@@ -216,38 +286,129 @@ func (c *APIClient) DoDELETE(ctx context.Context, endpointPath string, operation
 }
 
 // handleResponse processes the HTTP response and decodes the result.
+// Successful responses are decoded directly from the body, without buffering it.
 func (c *APIClient) handleResponse(ctx context.Context, resp *http.Response, result any, operation string) error {
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return c.ErrorLogWrapper(ctx, fmt.Errorf("read response body: %w", err))
-	}
-
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, err := io.ReadAll(c.limitResponseBody(resp.Body))
+		if err != nil {
+			return c.ErrorLogWrapper(ctx, fmt.Errorf("read response body: %w", err))
+		}
 		return c.parseHTTPError(ctx, resp.StatusCode, bodyBytes, operation)
 	}
 
-	if result != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, result); err != nil {
-			return c.ErrorLogWrapper(ctx, fmt.Errorf("decode response: %w", err))
+	if result == nil {
+		return nil
+	}
+
+	body := c.limitResponseBody(resp.Body)
+	if err := json.NewDecoder(body).Decode(result); err != nil {
+		if body.N <= 0 {
+			return c.ErrorLogWrapper(ctx, c.responseTooLargeError(operation))
 		}
+		if errors.Is(err, io.EOF) {
+			return nil // empty body
+		}
+		return c.ErrorLogWrapper(ctx, fmt.Errorf("decode response: %w", err))
 	}
 
 	return nil
 }
 
+// readJSONBody reads a JSON response body up to the response size limit.
+func (c *APIClient) readJSONBody(reader io.Reader, operation string) ([]byte, error) {
+	body := c.limitResponseBody(reader)
+	bodyBytes, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	if body.N <= 0 {
+		return nil, c.responseTooLargeError(operation)
+	}
+	return bodyBytes, nil
+}
+
+// limitResponseBody returns a reader that stops one byte past the response size limit,
+// so exhausting it (N <= 0) means the body is too large.
+func (c *APIClient) limitResponseBody(reader io.Reader) *io.LimitedReader {
+	limit := c.maxResponseBytes
+	if limit <= 0 {
+		limit = math.MaxInt64 - 1
+	}
+	return &io.LimitedReader{R: reader, N: limit + 1}
+}
+
+// responseTooLargeError reports a response body exceeding the size limit.
+func (c *APIClient) responseTooLargeError(operation string) error {
+	return domain.ResponseTooLargeError{
+		Service:   domain.Service(c.serviceName),
+		Operation: operation,
+		MaxBytes:  c.maxResponseBytes,
+	}
+}
+
+// executeRequest performs a request and records its outcome in the upstream request metrics and the audit log.
+// Requests that may modify data invalidate cached responses of the affected endpoints;
+// read-only requests such as Tracker searches do not.
+func (c *APIClient) executeRequest(
+	ctx context.Context,
+	method, endpointPath string,
+	body any,
+	header http.Header,
+	operation string,
+) (*http.Response, error) {
+	if !isIdempotentRequest(method, endpointPath) {
+		defer c.cache.invalidate(endpointPath)
+	}
+
+	start := time.Now()
+	resp, err := c.executeGuardedRequest(ctx, method, endpointPath, body, header)
+
+	status := upstreamStatus(resp, err)
+	c.observeUpstreamRequest(operation, status, time.Since(start))
+	c.auditOperation(ctx, method, endpointPath, operation, status)
+
+	return resp, err
+}
+
+// executeGuardedRequest performs a request guarded by the circuit breaker,
+// failing fast while the service is unavailable.
+func (c *APIClient) executeGuardedRequest(
+	ctx context.Context,
+	method, endpointPath string,
+	body any,
+	header http.Header,
+) (*http.Response, error) {
+	if c.breaker == nil {
+		return c.executeRequestWithRetry(ctx, method, endpointPath, body, header)
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.executeRequestWithRetry(ctx, method, endpointPath, body, header)
+	c.breaker.record(ctx, classifyOutcome(ctx, resp, err))
+
+	return resp, err
+}
+
 // executeRequestWithAuthRetry performs a request and retries once after forced token refresh
 // when the upstream responds with authentication/authorization errors.
+// The resendCount is the number of earlier attempts of the same request.
 func (c *APIClient) executeRequestWithAuthRetry(
 	ctx context.Context,
 	method, endpointPath string,
 	body any,
+	header http.Header,
+	resendCount int,
 ) (*http.Response, error) {
-	resp, err := c.executeHTTPRequest(ctx, method, endpointPath, body, false)
+	resp, err := c.executeHTTPRequest(ctx, method, endpointPath, body, header, resendCount, false)
 	if err != nil {
 		return nil, err
 	}
 
-	if !isAuthRetryStatus(resp.StatusCode) {
+	// the caller's own token cannot be refreshed, so its rejection is final
+	if _, isCaller := callerauth.FromContext(ctx); isCaller || !isAuthRetryStatus(resp.StatusCode) {
 		return resp, nil
 	}
 
@@ -255,7 +416,7 @@ func (c *APIClient) executeRequestWithAuthRetry(
 		slog.WarnContext(ctx, "failed to close response body before retry", "error", closeErr)
 	}
 
-	resp, err = c.executeHTTPRequest(ctx, method, endpointPath, body, true)
+	resp, err = c.executeHTTPRequest(ctx, method, endpointPath, body, header, resendCount+1, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed retry after token refresh: %w", err)
 	}
@@ -351,10 +512,60 @@ func (c *APIClient) resolveRequestURL(endpointPath string) (string, error) {
 }
 
 // executeHTTPRequest performs a single HTTP request with token injection and optional body encoding.
+// The header adds request-specific headers such as If-None-Match. Each request is traced in its own span.
 func (c *APIClient) executeHTTPRequest(
 	ctx context.Context,
 	method, endpointPath string,
 	body any,
+	header http.Header,
+	resendCount int,
+	tokenForceRefresh bool,
+) (*http.Response, error) {
+	template := endpointTemplate(endpointPath)
+	ctx, span := c.tracer.Start(ctx, method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLTemplate(template),
+			attribute.String(attrService, c.serviceName),
+		),
+	)
+	defer span.End()
+
+	if c.baseURL != nil {
+		span.SetAttributes(semconv.ServerAddress(c.baseURL.Hostname()))
+	}
+	if resendCount > 0 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(resendCount))
+	}
+
+	resp, err := c.sendHTTPRequest(ctx, method, endpointPath, body, header, tokenForceRefresh)
+	if err != nil {
+		spanErr := err
+		// url.Error includes the request URL with its query, which may carry user search text
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			spanErr = urlErr.Err
+		}
+		span.RecordError(spanErr)
+		span.SetStatus(codes.Error, spanErr.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// sendHTTPRequest builds the request, waits for the rate limiter and sends the request.
+func (c *APIClient) sendHTTPRequest(
+	ctx context.Context,
+	method, endpointPath string,
+	body any,
+	header http.Header,
 	tokenForceRefresh bool,
 ) (*http.Response, error) {
 	requestURL, err := c.resolveRequestURL(endpointPath)
@@ -362,7 +573,8 @@ func (c *APIClient) executeHTTPRequest(
 		return nil, fmt.Errorf("resolve request URL: %w", err)
 	}
 
-	token, err := c.tokenProvider.Token(ctx, tokenForceRefresh)
+	tokenProvider := c.tokenProviderFor(ctx)
+	token, err := tokenProvider.Token(ctx, tokenForceRefresh)
 	if err != nil {
 		return nil, err
 	}
@@ -381,17 +593,29 @@ func (c *APIClient) executeHTTPRequest(
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
-	req.Header.Set(HeaderAuthorization, "Bearer "+token)
-	req.Header.Set(HeaderCloudOrgID, c.orgID)
+	req.Header.Set(HeaderAuthorization, tokenProvider.AuthScheme()+" "+token)
+	req.Header.Set(c.orgIDHeader, c.orgID)
 	req.Header.Set(HeaderContentType, ContentTypeJSON)
 
 	for key, value := range c.extraHeaders {
 		req.Header.Set(key, value)
 	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	if c.limiter != nil {
+		waitStart := time.Now()
+		if err = c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("wait for rate limit: %w", err)
+		}
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int64(attrRateLimitWaitMS, time.Since(waitStart).Milliseconds()))
+	}
 
 	resp, err := c.httpDoer.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, &transportError{err: err}
 	}
 	return resp, nil
 }
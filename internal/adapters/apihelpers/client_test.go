@@ -2,15 +2,21 @@ package apihelpers
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
 )
 
 // newTestAPIClient creates an API client with injected fake dependencies.
@@ -26,10 +32,18 @@ func newTestAPIClient(doer IHTTPDoer, provider ITokenProvider) *APIClient {
 		baseURL:             parsedBaseURL,
 		baseURLParseErr:     nil,
 		orgID:               "org-id",
+		orgIDHeader:         HeaderCloudOrgID,
 		extraHeaders:        nil,
 		serviceName:         "test-service",
 		parseError:          nil,
 		rawResponseMaxBytes: 0,
+		maxResponseBytes:    0,
+		retry:               RetryPolicy{MaxAttempts: 0, BaseDelay: 0, MaxDelay: 0},
+		limiter:             nil,
+		breaker:             nil,
+		cache:               nil,
+		tracer:              noop.NewTracerProvider().Tracer(""),
+		metrics:             nil,
 	}
 }
 
@@ -90,10 +104,18 @@ func TestResolveRequestURL_Validation(t *testing.T) {
 		baseURL:             parsedBaseURL,
 		baseURLParseErr:     nil,
 		orgID:               "",
+		orgIDHeader:         HeaderCloudOrgID,
 		extraHeaders:        nil,
 		serviceName:         "",
 		parseError:          nil,
 		rawResponseMaxBytes: 0,
+		maxResponseBytes:    0,
+		retry:               RetryPolicy{MaxAttempts: 0, BaseDelay: 0, MaxDelay: 0},
+		limiter:             nil,
+		breaker:             nil,
+		cache:               nil,
+		tracer:              noop.NewTracerProvider().Tracer(""),
+		metrics:             nil,
 	}
 
 	testCases := []struct {
@@ -133,10 +155,17 @@ func TestResolveRequestURL_Validation(t *testing.T) {
 			baseURL:             parsedBaseURL,
 			baseURLParseErr:     errors.New("invalid base URL"),
 			orgID:               "",
+			orgIDHeader:         HeaderCloudOrgID,
 			extraHeaders:        nil,
 			serviceName:         "",
 			parseError:          nil,
 			rawResponseMaxBytes: 0,
+			retry:               RetryPolicy{MaxAttempts: 0, BaseDelay: 0, MaxDelay: 0},
+			limiter:             nil,
+			breaker:             nil,
+			cache:               nil,
+			tracer:              noop.NewTracerProvider().Tracer(""),
+			metrics:             nil,
 		}
 
 		_, err := clientWithParseError.resolveRequestURL("/v1/pages")
@@ -152,10 +181,17 @@ func TestResolveRequestURL_Validation(t *testing.T) {
 			baseURL:             nil,
 			baseURLParseErr:     nil,
 			orgID:               "",
+			orgIDHeader:         HeaderCloudOrgID,
 			extraHeaders:        nil,
 			serviceName:         "",
 			parseError:          nil,
 			rawResponseMaxBytes: 0,
+			retry:               RetryPolicy{MaxAttempts: 0, BaseDelay: 0, MaxDelay: 0},
+			limiter:             nil,
+			breaker:             nil,
+			cache:               nil,
+			tracer:              noop.NewTracerProvider().Tracer(""),
+			metrics:             nil,
 		}
 
 		_, err := clientWithNilBase.resolveRequestURL("/v1/pages")
@@ -171,6 +207,7 @@ func TestDoGETRaw_RetriesAfterUnauthorized(t *testing.T) {
 
 	doer := NewMockIHTTPDoer(ctrl)
 	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
 
 	firstResponse := &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
 		StatusCode: http.StatusUnauthorized,
@@ -206,6 +243,7 @@ func TestDoGETRaw_DoesNotRetryOnNonAuthStatus(t *testing.T) {
 
 	doer := NewMockIHTTPDoer(ctrl)
 	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
 
 	errorResponse := &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
 		StatusCode: http.StatusInternalServerError,
@@ -230,6 +268,7 @@ func TestDoGETStream_RetriesAfterForbidden(t *testing.T) {
 
 	doer := NewMockIHTTPDoer(ctrl)
 	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
 
 	firstResponse := &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
 		StatusCode: http.StatusForbidden,
@@ -272,6 +311,7 @@ func TestDoGETStream_RetryFailureIsWrapped(t *testing.T) {
 
 	doer := NewMockIHTTPDoer(ctrl)
 	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
 
 	firstResponse := &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
 		StatusCode: http.StatusUnauthorized,
@@ -294,3 +334,184 @@ func TestDoGETStream_RetryFailureIsWrapped(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed retry after token refresh")
 }
+
+// TestDoGET_UsesProviderAuthScheme verifies the Authorization header scheme comes from the token provider.
+func TestDoGET_UsesProviderAuthScheme(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	doer := NewMockIHTTPDoer(ctrl)
+	provider := NewMockITokenProvider(ctrl)
+
+	provider.EXPECT().Token(gomock.Any(), false).Return("oauth-token", nil)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeOAuth)
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "OAuth oauth-token", req.Header.Get(HeaderAuthorization))
+		//nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	client := newTestAPIClient(doer, provider)
+
+	_, err := client.DoGET(t.Context(), "/v1/resource", nil, "operation")
+	require.NoError(t, err)
+}
+
+func TestOrgIDHeader(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		orgType config.OrgType
+		want    string
+	}{
+		{name: "cloud", orgType: config.OrgTypeCloud, want: HeaderCloudOrgID},
+		{name: "yandex 360", orgType: config.OrgType360, want: HeaderOrgID},
+		{name: "unset", orgType: "", want: HeaderCloudOrgID},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.want, OrgIDHeader(testCase.orgType))
+		})
+	}
+}
+
+func TestDoGET_SendsConfiguredOrgIDHeader(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	doer := NewMockIHTTPDoer(ctrl)
+	provider := NewMockITokenProvider(ctrl)
+
+	provider.EXPECT().Token(gomock.Any(), false).Return("token", nil)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer)
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "org-360", req.Header.Get(HeaderOrgID))
+		assert.Empty(t, req.Header.Get(HeaderCloudOrgID))
+		//nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	//nolint:exhaustruct // only org and base URL settings are relevant
+	client := NewAPIClient(APIClientConfig{
+		BaseURL:     "https://api.example.test",
+		OrgID:       "org-360",
+		OrgIDHeader: HeaderOrgID,
+	})
+	client.httpDoer = doer
+	client.tokenProvider = provider
+
+	_, err := client.DoGET(t.Context(), "/v1/resource", nil, "operation")
+	require.NoError(t, err)
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newRateLimiter(0, 10))
+
+	limiter := newRateLimiter(2.5, 0)
+	require.NotNil(t, limiter)
+	assert.InDelta(t, 2.5, float64(limiter.Limit()), 0)
+	assert.Equal(t, 1, limiter.Burst())
+}
+
+func TestDoGET_WaitsForRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	doer := NewMockIHTTPDoer(ctrl)
+	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
+	provider.EXPECT().Token(gomock.Any(), false).Return("token", nil).AnyTimes()
+
+	// Only the first request fits into the burst; the second cannot get a token before the deadline.
+	doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+		//nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString("{}")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	client := newTestAPIClient(doer, provider)
+	client.limiter = newRateLimiter(0.01, 1)
+
+	_, err := client.DoGET(t.Context(), "/v1/resource", nil, "operation")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	_, err = client.DoGET(ctx, "/v1/resource", nil, "operation")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wait for rate limit")
+}
+
+func TestDoGET_LimitsResponseSize(t *testing.T) {
+	t.Parallel()
+
+	const maxBytes = 16
+
+	testCases := []struct {
+		name      string
+		path      string
+		body      string
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "within limit", path: "/v1/resource", body: `{"value":"abc"}`, wantValue: "abc", wantErr: false},
+		{name: "empty body", path: "/v1/resource", body: "", wantValue: "", wantErr: false},
+		{name: "exceeds limit", path: "/v1/resource", body: `{"value":"abcdefgh"}`, wantValue: "", wantErr: true},
+		{
+			name:      "cached response exceeds limit",
+			path:      "/v1/cached/1",
+			body:      `{"value":"abcdefgh"}`,
+			wantValue: "",
+			wantErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			doer, provider := newRetryMocks(t)
+			doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, testCase.body, ""), nil)
+
+			client, _ := newCacheTestClient(doer, provider)
+			client.maxResponseBytes = maxBytes
+
+			var result cachedValue
+			_, err := client.DoGET(t.Context(), testCase.path, &result, "GetResource")
+
+			if !testCase.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.wantValue, result.Value)
+				return
+			}
+
+			var tooLargeErr domain.ResponseTooLargeError
+			require.ErrorAs(t, err, &tooLargeErr)
+			assert.Equal(t, domain.ResponseTooLargeError{
+				Service:   "test-service",
+				Operation: "GetResource",
+				MaxBytes:  maxBytes,
+			}, tooLargeErr)
+		})
+	}
+}
@@ -6,9 +6,16 @@ import "time"
 const (
 	HeaderAuthorization = "Authorization"
 	HeaderCloudOrgID    = "X-Cloud-Org-Id"
+	HeaderOrgID         = "X-Org-ID"
 	HeaderContentType   = "Content-Type"
+	HeaderRetryAfter    = "Retry-After"
+	HeaderETag          = "ETag"
+	HeaderIfNoneMatch   = "If-None-Match"
 
 	ContentTypeJSON = "application/json"
 
+	AuthSchemeBearer = "Bearer"
+	AuthSchemeOAuth  = "OAuth"
+
 	DefaultTimeout = 30 * time.Second
 )
@@ -0,0 +1,278 @@
+package apihelpers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// fixtureFilePerm restricts fixtures to the server user, as they contain API responses.
+	fixtureFilePerm = 0o600
+	// fixtureDirPerm is used when the fixtures directory has to be created.
+	fixtureDirPerm = 0o700
+	// fixtureKeyLen is the number of hex digits of the request hash in fixture file names.
+	fixtureKeyLen = 16
+	// maxFixtureSlugLen bounds the readable request part of fixture file names.
+	maxFixtureSlugLen = 80
+)
+
+// errFixtureNotFound reports a replayed request without a recorded response. It is not retried.
+var errFixtureNotFound = errors.New("no recorded response")
+
+// fixture is a recorded HTTP exchange.
+type fixture struct {
+	Request  fixtureMessage `json:"request"`
+	Response fixtureMessage `json:"response"`
+}
+
+// fixtureMessage is a recorded request or response.
+// JSON bodies are stored as is for readability, other bodies are base64-encoded in RawBody.
+type fixtureMessage struct {
+	Method  string          `json:"method,omitempty"`
+	URL     string          `json:"url,omitempty"`
+	Status  int             `json:"status,omitempty"`
+	Header  http.Header     `json:"header,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	RawBody []byte          `json:"raw_body,omitempty"`
+}
+
+// prefixedBody is a response body whose beginning was already read: it reads the read part
+// before the rest of the original body and closes the original body.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// fixtureTransport records HTTP exchanges to a directory or replays them from it.
+// Repeated identical requests are numbered in order, so a session that reads a resource before
+// and after changing it is replayed faithfully; once the recorded responses run out, the last one is repeated.
+// Responses larger than maxBodyBytes are passed through without being recorded.
+type fixtureTransport struct {
+	dir          string
+	base         http.RoundTripper // nil in replay mode
+	maxBodyBytes int64
+
+	mu       sync.Mutex
+	sequence map[string]int // number of exchanges served per request key
+}
+
+// newFixtureTransport creates a transport that records exchanges made through base into dir,
+// or replays them from dir when base is nil. Only responses of up to maxBodyBytes are recorded.
+func newFixtureTransport(dir string, base http.RoundTripper, maxBodyBytes int64) *fixtureTransport {
+	return &fixtureTransport{
+		dir:          dir,
+		base:         base,
+		maxBodyBytes: maxBodyBytes,
+		mu:           sync.Mutex{},
+		sequence:     make(map[string]int),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fixtureName(req, requestBody)
+	if t.base == nil {
+		return t.replay(req, name)
+	}
+	return t.record(req, name, requestBody)
+}
+
+// record performs the request and saves the exchange. Failures to save are logged, not returned.
+// A response body over the size limit is streamed to the caller as is, so the limits of the caller apply to it.
+func (t *fixtureTransport) record(req *http.Request, name string, requestBody []byte) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, t.maxBodyBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	if int64(len(responseBody)) > t.maxBodyBytes {
+		resp.Body = prefixedBody{Reader: io.MultiReader(bytes.NewReader(responseBody), resp.Body), Closer: resp.Body}
+		slog.WarnContext(req.Context(), "HTTP fixture not recorded: response body exceeds the size limit",
+			"method", req.Method, "path", req.URL.Path, "limit_bytes", t.maxBodyBytes)
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	recorded := fixture{
+		Request:  newFixtureMessage(req.Method, req.URL.String(), 0, req.Header, requestBody),
+		Response: newFixtureMessage("", "", resp.StatusCode, resp.Header, responseBody),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sequence[name]++
+	if err = t.save(t.fixturePath(name, t.sequence[name]), recorded); err != nil {
+		slog.ErrorContext(req.Context(), "failed to record HTTP fixture", "error", err)
+	}
+
+	return resp, nil
+}
+
+// replay serves the next recorded response of the request.
+func (t *fixtureTransport) replay(req *http.Request, name string) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	next := t.sequence[name] + 1
+	recorded, err := t.load(t.fixturePath(name, next))
+	switch {
+	case err == nil:
+		t.sequence[name] = next
+	case errors.Is(err, errFixtureNotFound) && next > 1:
+		recorded, err = t.load(t.fixturePath(name, next-1))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("replay %s %s: %w", req.Method, req.URL.Path, err)
+	}
+
+	body := recorded.Response.body()
+	return &http.Response{ //nolint:exhaustruct // optional http.Response fields are not recorded
+		Status:        strconv.Itoa(recorded.Response.Status) + " " + http.StatusText(recorded.Response.Status),
+		StatusCode:    recorded.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Response.Header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes a fixture file, creating the fixtures directory if needed.
+func (t *fixtureTransport) save(path string, recorded fixture) error {
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode fixture: %w", err)
+	}
+	if err = os.MkdirAll(t.dir, fixtureDirPerm); err != nil {
+		return fmt.Errorf("create fixtures directory: %w", err)
+	}
+	if err = os.WriteFile(path, append(data, '\n'), fixtureFilePerm); err != nil {
+		return fmt.Errorf("write fixture: %w", err)
+	}
+	return nil
+}
+
+// load reads a fixture file.
+func (t *fixtureTransport) load(path string) (fixture, error) {
+	var recorded fixture
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return recorded, errFixtureNotFound
+	}
+	if err != nil {
+		return recorded, fmt.Errorf("read fixture: %w", err)
+	}
+	if err = json.Unmarshal(data, &recorded); err != nil {
+		return recorded, fmt.Errorf("decode fixture %s: %w", filepath.Base(path), err)
+	}
+	return recorded, nil
+}
+
+// fixturePath returns the file of the n-th exchange of the request.
+func (t *fixtureTransport) fixturePath(name string, n int) string {
+	return filepath.Join(t.dir, name+"_"+strconv.Itoa(n)+".json")
+}
+
+// readRequestBody reads the request body and restores it for sending.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// fixtureName identifies a request by its method, URL, If-None-Match header and body. It starts with
+// a readable form of the method and path and ends with a hash, so different queries get different files.
+// A revalidation of a cached response is thus never answered with a response recorded for a plain request
+// or the other way round, whatever the state of the response cache on replay.
+func fixtureName(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	if etag := req.Header.Get(HeaderIfNoneMatch); etag != "" {
+		hash.Write([]byte(HeaderIfNoneMatch + ": " + etag + "\n"))
+	}
+	hash.Write(body)
+	key := hex.EncodeToString(hash.Sum(nil))[:fixtureKeyLen]
+
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.ToLower(req.Method)+req.URL.Path)
+	if len(slug) > maxFixtureSlugLen {
+		slug = slug[:maxFixtureSlugLen]
+	}
+
+	return slug + "_" + key
+}
+
+// newFixtureMessage records a message with its credentials and organization headers stripped.
+func newFixtureMessage(method, rawURL string, status int, header http.Header, body []byte) fixtureMessage {
+	header = header.Clone()
+	for _, name := range []string{HeaderAuthorization, HeaderCloudOrgID, HeaderOrgID, "Cookie", "Set-Cookie"} {
+		header.Del(name)
+	}
+	header.Del("Content-Length") // the body may be edited by hand
+
+	message := fixtureMessage{
+		Method:  method,
+		URL:     rawURL,
+		Status:  status,
+		Header:  header,
+		Body:    nil,
+		RawBody: nil,
+	}
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		message.Body = body
+	default:
+		message.RawBody = body
+	}
+	return message
+}
+
+// body returns the recorded message body.
+func (m fixtureMessage) body() []byte {
+	if m.Body != nil {
+		return m.Body
+	}
+	return m.RawBody
+}
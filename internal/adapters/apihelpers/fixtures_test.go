@@ -0,0 +1,250 @@
+package apihelpers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// testFixtureMaxBytes is the size limit of recorded response bodies in tests.
+const testFixtureMaxBytes = 1 << 20
+
+// sendFixtureRequest sends a request with credentials through the client and returns the response body.
+func sendFixtureRequest(t *testing.T, client *http.Client, method, rawURL, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, rawURL, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set(HeaderAuthorization, "Bearer secret-token")
+	req.Header.Set(HeaderCloudOrgID, "secret-org")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestFixtureTransport_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret-cookie"}) //nolint:exhaustruct // test cookie
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":` + strconv.Itoa(int(n)) + `}`))
+	}))
+
+	dir := t.TempDir()
+	recorder := &http.Client{ //nolint:exhaustruct // defaults
+		Transport: newFixtureTransport(dir, http.DefaultTransport, testFixtureMaxBytes),
+	}
+	for _, want := range []string{`{"version":1}`, `{"version":2}`} {
+		status, body := sendFixtureRequest(t, recorder, http.MethodGet, server.URL+"/v3/issues/TEST-1", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, want, body)
+	}
+	status, body := sendFixtureRequest(t, recorder, http.MethodPost, server.URL+"/v3/issues", `{"summary":"a"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, `{"summary":"a"}`, body)
+
+	server.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	for _, file := range files {
+		data, readErr := os.ReadFile(file)
+		require.NoError(t, readErr)
+		assert.NotContains(t, string(data), "secret", "credentials must be stripped from %s", file)
+	}
+
+	replayer := &http.Client{ //nolint:exhaustruct // defaults
+		Transport: newFixtureTransport(dir, nil, testFixtureMaxBytes),
+	}
+	for _, want := range []string{`{"version":1}`, `{"version":2}`, `{"version":2}`} {
+		status, body = sendFixtureRequest(t, replayer, http.MethodGet, server.URL+"/v3/issues/TEST-1", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, want, body)
+	}
+	status, body = sendFixtureRequest(t, replayer, http.MethodPost, server.URL+"/v3/issues", `{"summary":"a"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"summary":"a"}`, body)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/v3/issues/TEST-2", http.NoBody)
+	require.NoError(t, err)
+	_, err = replayer.Do(req) //nolint:bodyclose // no response on error
+	require.ErrorIs(t, err, errFixtureNotFound)
+
+	assert.Equal(t, int32(3), hits.Load(), "replay must not reach the server")
+}
+
+func TestFixtureTransport_DoesNotRecordLargeResponses(t *testing.T) {
+	t.Parallel()
+
+	large := strings.Repeat("x", 64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = w.Write([]byte(large))
+			return
+		}
+		_, _ = w.Write([]byte(`{"small":true}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder := &http.Client{ //nolint:exhaustruct // defaults
+		Transport: newFixtureTransport(dir, http.DefaultTransport, int64(len(large))-1),
+	}
+
+	status, body := sendFixtureRequest(t, recorder, http.MethodGet, server.URL+"/large", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, large, body, "the caller must receive the whole body")
+
+	status, body = sendFixtureRequest(t, recorder, http.MethodGet, server.URL+"/small", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"small":true}`, body)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, filepath.Base(files[0]), "get_small_")
+}
+
+func TestFixtureTransport_ReplaysCachedSession(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderETag, `"v1"`)
+		if r.Header.Get(HeaderIfNoneMatch) == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":"first"}`))
+	}))
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	_, provider := newRetryMocks(t)
+	newCachingClient := func(transport *fixtureTransport) (*APIClient, *time.Time) {
+		client, now := newCacheTestClient(&http.Client{Transport: transport}, provider) //nolint:exhaustruct // defaults
+		client.baseURL = serverURL
+		return client, now
+	}
+	// getTwice reads the resource, lets the cached response expire and reads it again with revalidation
+	getTwice := func(client *APIClient, now *time.Time) {
+		for range 2 {
+			var result cachedValue
+			_, getErr := client.DoGET(t.Context(), "/v1/cached/item", &result, "operation")
+			require.NoError(t, getErr)
+			assert.Equal(t, "first", result.Value)
+			*now = now.Add(2 * time.Minute)
+		}
+	}
+
+	dir := t.TempDir()
+	getTwice(newCachingClient(newFixtureTransport(dir, http.DefaultTransport, testFixtureMaxBytes)))
+	server.Close()
+
+	// the replaying server starts with an empty cache, so its first request is not conditional,
+	// although the last recorded exchange of the resource is a revalidation
+	replayer := newFixtureTransport(dir, nil, testFixtureMaxBytes)
+	getTwice(newCachingClient(replayer))
+	getTwice(newCachingClient(replayer))
+}
+
+func TestFixtureTransport_ReplaysBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.test/file", http.NoBody)
+	require.NoError(t, err)
+
+	transport := newFixtureTransport(dir, nil, testFixtureMaxBytes)
+	binary := []byte{0x00, 0xff, 0x10}
+	recorded := fixture{
+		Request:  newFixtureMessage(req.Method, req.URL.String(), 0, req.Header, nil),
+		Response: newFixtureMessage("", "", http.StatusOK, make(http.Header), binary),
+	}
+	require.NoError(t, transport.save(transport.fixturePath(fixtureName(req, nil), 1), recorded))
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(binary, body))
+	assert.Equal(t, int64(len(binary)), resp.ContentLength)
+}
+
+func TestDoGET_DoesNotRetryMissingFixture(t *testing.T) {
+	t.Parallel()
+
+	_, provider := newRetryMocks(t)
+	client := newTestAPIClient(
+		&http.Client{Transport: newFixtureTransport(t.TempDir(), nil, testFixtureMaxBytes)}, //nolint:exhaustruct // defaults
+		provider,
+	)
+	client.retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	client.breaker = newCircuitBreaker("test-service", 1, time.Hour)
+
+	for range 2 {
+		_, err := client.DoGET(t.Context(), "/v1/resource", nil, "GetResource")
+		require.ErrorIs(t, err, errFixtureNotFound)
+	}
+}
+
+func TestNewHTTPClient_FixturesMode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		mode       config.FixturesMode
+		wantReplay bool
+	}{
+		{name: "record", mode: config.FixturesModeRecord, wantReplay: false},
+		{name: "replay", mode: config.FixturesModeReplay, wantReplay: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newTransportTestConfig()
+			cfg.OrgName = "work"
+			cfg.HTTPFixturesMode = testCase.mode
+			cfg.HTTPFixturesDir = "/var/lib/yandex-mcp/fixtures"
+
+			client, err := NewHTTPClient(cfg)
+			require.NoError(t, err)
+
+			transport, ok := client.Transport.(*fixtureTransport)
+			require.True(t, ok)
+			assert.Equal(t, "/var/lib/yandex-mcp/fixtures/work", transport.dir)
+			assert.Equal(t, testCase.wantReplay, transport.base == nil)
+		})
+	}
+}
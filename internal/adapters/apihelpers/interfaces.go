@@ -4,14 +4,17 @@ package apihelpers
 import (
 	"context"
 	"net/http"
+	"time"
 )
 
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -source=interfaces.go -destination=mock_interfaces.go -package=apihelpers
 
-// ITokenProvider provides IAM tokens for API authentication.
+// ITokenProvider provides tokens for API authentication.
 type ITokenProvider interface {
-	// Token returns a valid IAM token, refreshing if needed.
+	// Token returns a valid token, refreshing if needed.
 	Token(ctx context.Context, forceRefresh bool) (string, error)
+	// AuthScheme returns the Authorization header scheme for issued tokens (e.g. "Bearer" or "OAuth").
+	AuthScheme() string
 }
 
 // IHTTPDoer abstracts HTTP request execution for client-level behavior and testability.
@@ -19,3 +22,12 @@ type IHTTPDoer interface {
 	// Do sends an HTTP request and returns an HTTP response.
 	Do(req *http.Request) (*http.Response, error)
 }
+
+// IMetrics records upstream request and response cache metrics.
+type IMetrics interface {
+	// ObserveUpstreamRequest records a completed upstream request including its retries.
+	// The status is the final HTTP status code, or "error" when no response was received.
+	ObserveUpstreamRequest(service, operation, status string, duration time.Duration)
+	// ObserveCacheLookup records a response cache lookup with result "hit", "miss" or "revalidated".
+	ObserveCacheLookup(service, result string)
+}
@@ -0,0 +1,39 @@
+package apihelpers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// upstreamStatusError labels upstream requests that failed without an HTTP response.
+	upstreamStatusError = "error"
+
+	// Response cache lookup results.
+	cacheResultHit         = "hit"
+	cacheResultMiss        = "miss"
+	cacheResultRevalidated = "revalidated"
+)
+
+// upstreamStatus returns the final HTTP status code of an upstream request, or "error" without a response.
+func upstreamStatus(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return upstreamStatusError
+	}
+	return strconv.Itoa(resp.StatusCode)
+}
+
+// observeUpstreamRequest records the outcome of an upstream request when metrics are enabled.
+func (c *APIClient) observeUpstreamRequest(operation, status string, duration time.Duration) {
+	if c.metrics != nil {
+		c.metrics.ObserveUpstreamRequest(c.serviceName, operation, status, duration)
+	}
+}
+
+// observeCacheLookup records a response cache lookup when metrics are enabled.
+func (c *APIClient) observeCacheLookup(result string) {
+	if c.metrics != nil {
+		c.metrics.ObserveCacheLookup(c.serviceName, result)
+	}
+}
@@ -0,0 +1,95 @@
+package apihelpers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDoGET_RecordsUpstreamRequestMetrics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		response   *http.Response
+		err        error
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			response:   newStatusResponse(http.StatusOK, ""),
+			err:        nil,
+			wantStatus: "200",
+			wantErr:    false,
+		},
+		{
+			name:       "client error",
+			response:   newStatusResponse(http.StatusNotFound, ""),
+			err:        nil,
+			wantStatus: "404",
+			wantErr:    true,
+		},
+		{
+			name:       "network error",
+			response:   nil,
+			err:        errors.New("connection reset"),
+			wantStatus: "error",
+			wantErr:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			doer, provider := newRetryMocks(t)
+			metrics := NewMockIMetrics(gomock.NewController(t))
+			client := newTestAPIClient(doer, provider)
+			client.metrics = metrics
+
+			doer.EXPECT().Do(gomock.Any()).Return(testCase.response, testCase.err)
+			metrics.EXPECT().ObserveUpstreamRequest("test-service", "GetThing", testCase.wantStatus, gomock.Any())
+
+			_, err := client.DoGET(t.Context(), "/v1/things/1", nil, "GetThing")
+			if testCase.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDoGET_RecordsCacheLookupMetrics(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	metrics := NewMockIMetrics(gomock.NewController(t))
+	client, now := newCacheTestClient(doer, provider)
+	client.metrics = metrics
+
+	metrics.EXPECT().ObserveUpstreamRequest("test-service", "op", gomock.Any(), gomock.Any()).Times(2)
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusOK, `{"value":"a"}`, `"v1"`), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(newBodyResponse(http.StatusNotModified, "", ""), nil),
+	)
+	gomock.InOrder(
+		metrics.EXPECT().ObserveCacheLookup("test-service", "miss"),
+		metrics.EXPECT().ObserveCacheLookup("test-service", "hit"),
+		metrics.EXPECT().ObserveCacheLookup("test-service", "revalidated"),
+	)
+
+	_, err := client.DoGET(t.Context(), "/v1/cached/1", nil, "op")
+	require.NoError(t, err)
+	_, err = client.DoGET(t.Context(), "/v1/cached/1", nil, "op")
+	require.NoError(t, err)
+
+	*now = now.Add(2 * time.Minute)
+	_, err = client.DoGET(t.Context(), "/v1/cached/1", nil, "op")
+	require.NoError(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,20 @@ func (m *MockITokenProvider) EXPECT() *MockITokenProviderMockRecorder {
 	return m.recorder
 }
 
+// AuthScheme mocks base method.
+func (m *MockITokenProvider) AuthScheme() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthScheme")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AuthScheme indicates an expected call of AuthScheme.
+func (mr *MockITokenProviderMockRecorder) AuthScheme() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthScheme", reflect.TypeOf((*MockITokenProvider)(nil).AuthScheme))
+}
+
 // Token mocks base method.
 func (m *MockITokenProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +109,51 @@ func (mr *MockIHTTPDoerMockRecorder) Do(req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockIHTTPDoer)(nil).Do), req)
 }
+
+// MockIMetrics is a mock of IMetrics interface.
+type MockIMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockIMetricsMockRecorder is the mock recorder for MockIMetrics.
+type MockIMetricsMockRecorder struct {
+	mock *MockIMetrics
+}
+
+// NewMockIMetrics creates a new mock instance.
+func NewMockIMetrics(ctrl *gomock.Controller) *MockIMetrics {
+	mock := &MockIMetrics{ctrl: ctrl}
+	mock.recorder = &MockIMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMetrics) EXPECT() *MockIMetricsMockRecorder {
+	return m.recorder
+}
+
+// ObserveCacheLookup mocks base method.
+func (m *MockIMetrics) ObserveCacheLookup(service, result string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveCacheLookup", service, result)
+}
+
+// ObserveCacheLookup indicates an expected call of ObserveCacheLookup.
+func (mr *MockIMetricsMockRecorder) ObserveCacheLookup(service, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveCacheLookup", reflect.TypeOf((*MockIMetrics)(nil).ObserveCacheLookup), service, result)
+}
+
+// ObserveUpstreamRequest mocks base method.
+func (m *MockIMetrics) ObserveUpstreamRequest(service, operation, status string, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveUpstreamRequest", service, operation, status, duration)
+}
+
+// ObserveUpstreamRequest indicates an expected call of ObserveUpstreamRequest.
+func (mr *MockIMetricsMockRecorder) ObserveUpstreamRequest(service, operation, status, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveUpstreamRequest", reflect.TypeOf((*MockIMetrics)(nil).ObserveUpstreamRequest), service, operation, status, duration)
+}
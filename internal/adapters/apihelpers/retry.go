@@ -0,0 +1,180 @@
+package apihelpers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/pause"
+)
+
+// RetryPolicy configures retries of idempotent requests on throttling, server and transient network errors.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one; values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles with every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than MaxDelay stops retrying.
+	MaxDelay time.Duration
+}
+
+// RetryPolicyFromConfig returns the retry policy configured for the organization profile.
+func RetryPolicyFromConfig(cfg *config.Config) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: cfg.HTTPRetryMaxAttempts,
+		BaseDelay:   cfg.HTTPRetryBaseDelay,
+		MaxDelay:    cfg.HTTPRetryMaxDelay,
+	}
+}
+
+// transportError marks failures of the HTTP round trip, as opposed to request preparation errors.
+type transportError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e *transportError) Error() string {
+	return "execute request: " + e.err.Error()
+}
+
+// Unwrap returns the underlying transport error.
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// executeRequestWithRetry performs a request and retries idempotent requests on retryable failures
+// with jittered exponential backoff.
+func (c *APIClient) executeRequestWithRetry(
+	ctx context.Context,
+	method, endpointPath string,
+	body any,
+	header http.Header,
+) (*http.Response, error) {
+	retryable := isIdempotentRequest(method, endpointPath)
+	// the query may carry user search text, so only the path is logged
+	logPath, _, _ := strings.Cut(endpointPath, "?")
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.executeRequestWithAuthRetry(ctx, method, endpointPath, body, header, attempt-1)
+		if !retryable || attempt >= c.retry.MaxAttempts {
+			return resp, err
+		}
+
+		delay, ok := c.retryDelay(ctx, attempt, resp, err)
+		if !ok {
+			return resp, err
+		}
+
+		logAttrs := []any{
+			slog.String("service", c.serviceName),
+			slog.String("method", method),
+			slog.String("path", logPath),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+		}
+		if err != nil {
+			logAttrs = append(logAttrs, slog.String("error", err.Error()))
+		} else {
+			logAttrs = append(logAttrs, slog.Int("status", resp.StatusCode))
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				slog.WarnContext(ctx, "failed to close response body before retry", "error", closeErr)
+			}
+		}
+		slog.WarnContext(ctx, "retrying request", logAttrs...)
+
+		if !pause.Sleep(ctx, delay) {
+			return nil, &transportError{err: ctx.Err()}
+		}
+	}
+}
+
+// retryDelay decides whether a failed attempt should be retried and how long to wait before the next one.
+func (c *APIClient) retryDelay(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if err != nil {
+		var tErr *transportError
+		if !errors.As(err, &tErr) || ctx.Err() != nil || errors.Is(err, errFixtureNotFound) {
+			return 0, false
+		}
+	} else if !isRetryStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	delay := pause.Jitter(backoffDelay(c.retry.BaseDelay, c.retry.MaxDelay, attempt))
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get(HeaderRetryAfter), time.Now()); ok {
+			if retryAfter > c.retry.MaxDelay {
+				return 0, false
+			}
+			delay = max(delay, retryAfter)
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// isIdempotentRequest reports whether a request can be safely repeated:
+// GET requests and the read-only search and count POST endpoints.
+func isIdempotentRequest(method, endpointPath string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		path, _, _ := strings.Cut(endpointPath, "?")
+		return strings.HasSuffix(path, "/_search") || strings.HasSuffix(path, "/_count")
+	default:
+		return false
+	}
+}
+
+// isRetryStatus reports whether a response status indicates throttling or a transient server failure.
+func isRetryStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoffDelay returns the exponential backoff for the given attempt, capped at maxDelay.
+func backoffDelay(baseDelay, maxDelay time.Duration, attempt int) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
@@ -0,0 +1,226 @@
+package apihelpers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newRetryTestClient creates an API client with fast retries.
+func newRetryTestClient(doer IHTTPDoer, provider ITokenProvider) *APIClient {
+	client := newTestAPIClient(doer, provider)
+	client.retry = RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}
+	return client
+}
+
+// newStatusResponse creates a response with the given status and optional Retry-After header.
+func newStatusResponse(statusCode int, retryAfter string) *http.Response {
+	header := make(http.Header)
+	if retryAfter != "" {
+		header.Set(HeaderRetryAfter, retryAfter)
+	}
+	return &http.Response{ //nolint:exhaustruct // optional http.Response fields are irrelevant for this test case
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		Header:     header,
+	}
+}
+
+// newRetryMocks creates a doer and a token provider that always returns a token.
+func newRetryMocks(t *testing.T) (*MockIHTTPDoer, *MockITokenProvider) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	doer := NewMockIHTTPDoer(ctrl)
+	provider := NewMockITokenProvider(ctrl)
+	provider.EXPECT().AuthScheme().Return(AuthSchemeBearer).AnyTimes()
+	provider.EXPECT().Token(gomock.Any(), false).Return("token", nil).AnyTimes()
+	return doer, provider
+}
+
+func TestDoGET_RetriesTransientFailures(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		response *http.Response
+		err      error
+	}{
+		{name: "too many requests", response: newStatusResponse(http.StatusTooManyRequests, ""), err: nil},
+		{name: "bad gateway", response: newStatusResponse(http.StatusBadGateway, ""), err: nil},
+		{name: "service unavailable", response: newStatusResponse(http.StatusServiceUnavailable, "0"), err: nil},
+		{name: "network error", response: nil, err: errors.New("connection reset by peer")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			doer, provider := newRetryMocks(t)
+
+			gomock.InOrder(
+				doer.EXPECT().Do(gomock.Any()).Return(testCase.response, testCase.err),
+				doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusOK, ""), nil),
+			)
+
+			_, err := newRetryTestClient(doer, provider).DoGET(t.Context(), "/v1/resource", nil, "operation")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDoPOST_RetriesOnlyReadOnlyEndpoints(t *testing.T) {
+	t.Parallel()
+
+	t.Run("search is retried", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+
+		gomock.InOrder(
+			doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusServiceUnavailable, ""), nil),
+			doer.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"query":"q"}`, string(body))
+				return newStatusResponse(http.StatusOK, ""), nil
+			}),
+		)
+
+		_, err := newRetryTestClient(doer, provider).DoPOST(
+			t.Context(), "/v3/issues/_search?perPage=10", map[string]string{"query": "q"}, nil, "operation")
+		require.NoError(t, err)
+	})
+
+	t.Run("create is not retried", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusServiceUnavailable, ""), nil)
+
+		_, err := newRetryTestClient(doer, provider).DoPOST(
+			t.Context(), "/v3/issues", map[string]string{"summary": "s"}, nil, "operation")
+		require.Error(t, err)
+	})
+}
+
+func TestDoGET_StopsRetrying(t *testing.T) {
+	t.Parallel()
+
+	t.Run("after max attempts", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusBadGateway, ""), nil).Times(3)
+
+		_, err := newRetryTestClient(doer, provider).DoGET(t.Context(), "/v1/resource", nil, "operation")
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	})
+
+	t.Run("on non-retryable status", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusNotFound, ""), nil)
+
+		_, err := newRetryTestClient(doer, provider).DoGET(t.Context(), "/v1/resource", nil, "operation")
+		require.Error(t, err)
+	})
+
+	t.Run("when Retry-After exceeds max delay", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusTooManyRequests, "120"), nil)
+
+		_, err := newRetryTestClient(doer, provider).DoGET(t.Context(), "/v1/resource", nil, "operation")
+		require.Error(t, err)
+	})
+
+	t.Run("when delay exceeds context deadline", func(t *testing.T) {
+		t.Parallel()
+		doer, provider := newRetryMocks(t)
+		client := newRetryTestClient(doer, provider)
+		client.retry.BaseDelay = time.Minute
+		client.retry.MaxDelay = time.Minute
+
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusServiceUnavailable, ""), nil)
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+
+		_, err := client.DoGET(ctx, "/v1/resource", nil, "operation")
+		require.Error(t, err)
+	})
+
+	t.Run("on token errors", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		doer := NewMockIHTTPDoer(ctrl)
+		provider := NewMockITokenProvider(ctrl)
+
+		provider.EXPECT().Token(gomock.Any(), false).Return("", errors.New("no token"))
+
+		_, err := newRetryTestClient(doer, provider).DoGET(t.Context(), "/v1/resource", nil, "operation")
+		require.Error(t, err)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", want: 0, wantOK: false},
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "negative seconds", value: "-1", want: 0, wantOK: false},
+		{name: "http date", value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second, wantOK: true},
+		{name: "past http date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "garbage", value: "soon", want: 0, wantOK: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parseRetryAfter(testCase.value, now)
+			assert.Equal(t, testCase.wantOK, ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 100*time.Millisecond, backoffDelay(100*time.Millisecond, time.Second, 1))
+	assert.Equal(t, 400*time.Millisecond, backoffDelay(100*time.Millisecond, time.Second, 3))
+	assert.Equal(t, time.Second, backoffDelay(100*time.Millisecond, time.Second, 10))
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isIdempotentRequest(http.MethodGet, "/v3/issues/A-1"))
+	assert.True(t, isIdempotentRequest(http.MethodPost, "/v3/issues/_search"))
+	assert.True(t, isIdempotentRequest(http.MethodPost, "/v3/issues/_count?x=1"))
+	assert.False(t, isIdempotentRequest(http.MethodPost, "/v3/issues"))
+	assert.False(t, isIdempotentRequest(http.MethodPatch, "/v3/issues/A-1"))
+	assert.False(t, isIdempotentRequest(http.MethodDelete, "/v3/issues/A-1"))
+}
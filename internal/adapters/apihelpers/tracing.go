@@ -0,0 +1,51 @@
+package apihelpers
+
+import "strings"
+
+const (
+	// tracerName is the instrumentation scope of upstream request spans.
+	tracerName = "github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+
+	// attrService is the span attribute with the upstream service name.
+	attrService = "yandex.service"
+	// attrRateLimitWaitMS is the span attribute with the time spent waiting for the client-side rate limiter.
+	attrRateLimitWaitMS = "yandex.rate_limit.wait_ms"
+
+	// templatePlaceholder replaces identifiers in endpoint templates.
+	templatePlaceholder = "{id}"
+)
+
+// endpointTemplate reduces an endpoint path to a low-cardinality template for span names and attributes:
+// the query is dropped and identifiers are replaced with a placeholder, e.g.
+// "/v3/issues/TEST-1/comments?perPage=50" becomes "/v3/issues/{id}/comments".
+// A segment following a collection name is an identifier, except for actions such as "_search"
+// and the entity type following "entities".
+func endpointTemplate(endpointPath string) string {
+	path, _, _ := strings.Cut(endpointPath, "?")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	expectID := false
+	for i := 1; i < len(segments); i++ { // segments[0] is the API version
+		segment := segments[i]
+		switch {
+		case segment == "" || strings.HasPrefix(segment, "_"):
+		case expectID || !isCollectionName(segment):
+			segments[i] = templatePlaceholder
+			expectID = false
+		default:
+			expectID = segment != "entities"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// isCollectionName reports whether a path segment looks like a resource collection name.
+func isCollectionName(segment string) bool {
+	for _, r := range segment {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
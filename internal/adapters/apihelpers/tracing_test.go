@@ -0,0 +1,85 @@
+package apihelpers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		endpointPath string
+		want         string
+	}{
+		{endpointPath: "/v3/myself", want: "/v3/myself"},
+		{endpointPath: "/v3/queues/", want: "/v3/queues/"},
+		{endpointPath: "/v3/queues/TEST?expand=all", want: "/v3/queues/{id}"},
+		{endpointPath: "/v3/users/ivanov", want: "/v3/users/{id}"},
+		{endpointPath: "/v3/issues/_search?perPage=50", want: "/v3/issues/_search"},
+		{endpointPath: "/v3/issues/TEST-1/comments", want: "/v3/issues/{id}/comments"},
+		{endpointPath: "/v3/issues/TEST-1/attachments/42/report.pdf", want: "/v3/issues/{id}/attachments/{id}/{id}"},
+		{endpointPath: "/v3/entities/project/655f/comments", want: "/v3/entities/project/{id}/comments"},
+		{endpointPath: "/v1/pages?slug=team%2Fdocs", want: "/v1/pages"},
+		{endpointPath: "/v1/pages/123/grids", want: "/v1/pages/{id}/grids"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.endpointPath, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.want, endpointTemplate(testCase.endpointPath))
+		})
+	}
+}
+
+func TestDoGET_RecordsRequestSpans(t *testing.T) {
+	t.Parallel()
+
+	doer, provider := newRetryMocks(t)
+	client := newRetryTestClient(doer, provider)
+	recorder := tracetest.NewSpanRecorder()
+	client.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	gomock.InOrder(
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusServiceUnavailable, ""), nil),
+		doer.EXPECT().Do(gomock.Any()).Return(nil, &url.Error{
+			Op:  http.MethodGet,
+			URL: "https://api.example.test/v3/issues/TEST-1?expand=all",
+			Err: errors.New("connection reset"),
+		}),
+		doer.EXPECT().Do(gomock.Any()).Return(newStatusResponse(http.StatusOK, ""), nil),
+	)
+
+	_, err := client.DoGET(t.Context(), "/v3/issues/TEST-1?expand=all", nil, "operation")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	for _, span := range spans {
+		assert.Equal(t, "GET /v3/issues/{id}", span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String("url.template", "/v3/issues/{id}"))
+		assert.Contains(t, span.Attributes(), attribute.String("server.address", "api.example.test"))
+	}
+
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusServiceUnavailable))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "connection reset", spans[1].Status().Description, "the request URL must not be recorded")
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("http.request.resend_count", 1))
+
+	assert.Contains(t, spans[2].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	assert.Contains(t, spans[2].Attributes(), attribute.Int("http.request.resend_count", 2))
+	assert.Equal(t, codes.Unset, spans[2].Status().Code)
+}
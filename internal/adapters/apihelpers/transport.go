@@ -0,0 +1,149 @@
+package apihelpers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// defaultTLSHandshakeTimeout matches http.DefaultTransport for the case it was replaced by another round tripper.
+const defaultTLSHandshakeTimeout = 10 * time.Second
+
+// NewHTTPClient builds the HTTP client for Tracker and Wiki requests of the organization profile
+// from its proxy, TLS and connection pool settings. Both adapters of a profile may share the client.
+// In the fixtures record and replay modes, exchanges are recorded to or replayed from a subdirectory
+// of the fixtures directory named after the profile.
+func NewHTTPClient(cfg *config.Config) (*http.Client, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var roundTripper http.RoundTripper = transport
+	fixturesDir := filepath.Join(cfg.HTTPFixturesDir, cfg.OrgName)
+	switch cfg.HTTPFixturesMode {
+	case config.FixturesModeRecord:
+		roundTripper = newFixtureTransport(fixturesDir, transport, cfg.HTTPMaxResponseBytes)
+	case config.FixturesModeReplay:
+		roundTripper = newFixtureTransport(fixturesDir, nil, cfg.HTTPMaxResponseBytes)
+	case config.FixturesModeOff:
+	}
+
+	return &http.Client{ //nolint:exhaustruct // optional fields use defaults
+		Transport: roundTripper,
+		Timeout:   httpTimeout(cfg),
+	}, nil
+}
+
+// NewTokenHTTPClient builds the HTTP client for IAM token exchanges of the organization profile
+// with the proxy and TLS settings of API requests. Unlike NewHTTPClient, it never records or replays
+// fixtures, since token exchanges carry credentials.
+func NewTokenHTTPClient(cfg *config.Config) (*http.Client, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{ //nolint:exhaustruct // optional fields use defaults
+		Transport: transport,
+		Timeout:   httpTimeout(cfg),
+	}, nil
+}
+
+// NewMetadataHTTPClient builds the HTTP client for the compute metadata service. The service is link-local
+// and served over plain HTTP, so it is reached directly, bypassing the configured and environment proxies.
+func NewMetadataHTTPClient(cfg *config.Config) *http.Client {
+	transport := newDefaultTransport()
+	transport.Proxy = nil
+
+	return &http.Client{ //nolint:exhaustruct // optional fields use defaults
+		Transport: transport,
+		Timeout:   httpTimeout(cfg),
+	}
+}
+
+// newTransport builds the transport of the organization profile from its proxy, TLS and connection pool settings.
+func newTransport(cfg *config.Config) (*http.Transport, error) {
+	transport := newDefaultTransport()
+	transport.MaxIdleConns = cfg.HTTPMaxIdleConns
+	transport.MaxIdleConnsPerHost = cfg.HTTPMaxIdleConnsPerHost
+	transport.MaxConnsPerHost = cfg.HTTPMaxConnsPerHost
+	transport.IdleConnTimeout = cfg.HTTPIdleConnTimeout
+
+	if cfg.HTTPProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.HTTPProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// newDefaultTransport returns a copy of the default transport, or a transport with the standard
+// proxy and timeout settings when the default one was replaced.
+func newDefaultTransport() *http.Transport {
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return defaultTransport.Clone()
+	}
+	return &http.Transport{ //nolint:exhaustruct // optional fields use defaults
+		Proxy:               http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: defaultTLSHandshakeTimeout,
+	}
+}
+
+// httpTimeout returns the request timeout of the organization profile.
+func httpTimeout(cfg *config.Config) time.Duration {
+	if cfg.HTTPTimeout == 0 {
+		return DefaultTimeout
+	}
+	return cfg.HTTPTimeout
+}
+
+// newTLSConfig trusts the system roots plus the configured CA bundle and presents the client certificate, if any.
+func newTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ //nolint:exhaustruct // optional fields use defaults
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.HTTPCAFile != "" {
+		pemBytes, err := os.ReadFile(cfg.HTTPCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("CA file %s contains no PEM certificates", cfg.HTTPCAFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if cfg.HTTPClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.HTTPClientCertFile, cfg.HTTPClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,215 @@
+package apihelpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// newTransportTestConfig returns a configuration with the default connection pool settings.
+func newTransportTestConfig() *config.Config {
+	//nolint:exhaustruct // only transport settings are relevant
+	return &config.Config{
+		HTTPTimeout:             time.Second,
+		HTTPMaxIdleConns:        100,
+		HTTPMaxIdleConnsPerHost: 10,
+		HTTPMaxConnsPerHost:     0,
+		HTTPIdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// writePEM writes a single PEM block to a file in a temporary directory and returns its path.
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// newClientCertFiles creates a self-signed client certificate and key and returns their paths.
+func newClientCertFiles(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	//nolint:exhaustruct // optional certificate fields are irrelevant for this test case
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "yandex-mcp-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEM(t, "client.crt", "CERTIFICATE", certDER), writePEM(t, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func TestNewHTTPClient_AppliesTransportSettings(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTransportTestConfig()
+	cfg.HTTPProxyURL = "http://proxy.example.test:3128"
+	cfg.HTTPMaxConnsPerHost = 20
+
+	client, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.Timeout)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 100, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 20, transport.MaxConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.Empty(t, transport.TLSClientConfig.Certificates)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.tracker.yandex.net/v3", nil)
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.test:3128", proxyURL.String())
+}
+
+func TestNewHTTPClient_TrustsCABundle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := newTransportTestConfig()
+	cfg.HTTPCAFile = writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	client, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestNewHTTPClient_LoadsClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTransportTestConfig()
+	cfg.HTTPClientCertFile, cfg.HTTPClientKeyFile = newClientCertFiles(t)
+
+	client, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+}
+
+func TestNewHTTPClient_InvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "not-pem.txt")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	certFile, _ := newClientCertFiles(t)
+
+	testCases := []struct {
+		name    string
+		modify  func(cfg *config.Config)
+		wantErr string
+	}{
+		{
+			name:    "missing CA file",
+			modify:  func(cfg *config.Config) { cfg.HTTPCAFile = filepath.Join(dir, "missing.pem") },
+			wantErr: "read CA file",
+		},
+		{
+			name:    "CA file without certificates",
+			modify:  func(cfg *config.Config) { cfg.HTTPCAFile = notPEM },
+			wantErr: "contains no PEM certificates",
+		},
+		{
+			name: "invalid client key",
+			modify: func(cfg *config.Config) {
+				cfg.HTTPClientCertFile = certFile
+				cfg.HTTPClientKeyFile = notPEM
+			},
+			wantErr: "load client certificate",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newTransportTestConfig()
+			testCase.modify(cfg)
+
+			client, err := NewHTTPClient(cfg)
+			require.Error(t, err)
+			assert.Nil(t, client)
+			assert.Contains(t, err.Error(), testCase.wantErr)
+		})
+	}
+}
+
+func TestNewTokenHTTPClient_AppliesTransportSettingsWithoutFixtures(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTransportTestConfig()
+	cfg.HTTPProxyURL = "http://proxy.example.test:3128"
+	cfg.HTTPClientCertFile, cfg.HTTPClientKeyFile = newClientCertFiles(t)
+	cfg.HTTPFixturesMode = config.FixturesModeReplay
+	cfg.HTTPFixturesDir = t.TempDir()
+
+	client, err := NewTokenHTTPClient(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.Timeout)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok, "token exchanges must not be recorded or replayed")
+	assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost,
+		"https://iam.api.cloud.yandex.net/iam/v1/tokens", nil)
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.test:3128", proxyURL.String())
+}
+
+func TestNewMetadataHTTPClient_BypassesProxy(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTransportTestConfig()
+	cfg.HTTPProxyURL = "http://proxy.example.test:3128"
+
+	client := NewMetadataHTTPClient(cfg)
+	assert.Equal(t, time.Second, client.Timeout)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Nil(t, transport.Proxy)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
 	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/doctor"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	trackertools "github.com/n-r-w/yandex-mcp/internal/tools/tracker"
 )
@@ -22,20 +23,30 @@ type Client struct {
 	apiClient *apihelpers.APIClient
 }
 
-// Compile-time check that Client implements the tools interface.
-var _ trackertools.ITrackerAdapter = (*Client)(nil)
+// Compile-time checks that Client implements the consumer interfaces.
+var (
+	_ trackertools.ITrackerAdapter = (*Client)(nil)
+	_ doctor.ITrackerClient        = (*Client)(nil)
+)
 
 // NewClient creates a new Tracker API client.
-func NewClient(cfg *config.Config, tokenProvider apihelpers.ITokenProvider) *Client {
+// A nil httpClient uses a default client with the configured timeout; nil metrics disable request metrics.
+func NewClient(
+	cfg *config.Config,
+	tokenProvider apihelpers.ITokenProvider,
+	httpClient *http.Client,
+	metrics apihelpers.IMetrics,
+) *Client {
 	client := &Client{
 		apiClient: nil, // set below
 	}
 
 	client.apiClient = apihelpers.NewAPIClient(apihelpers.APIClientConfig{
-		HTTPClient:    nil, // uses default
+		HTTPClient:    httpClient,
 		TokenProvider: tokenProvider,
 		BaseURL:       strings.TrimSuffix(cfg.TrackerBaseURL, "/"),
 		OrgID:         cfg.CloudOrgID,
+		OrgIDHeader:   apihelpers.OrgIDHeader(cfg.OrgType),
 		ExtraHeaders: map[string]string{
 			headerAcceptLanguage: acceptLangEN,
 		},
@@ -43,6 +54,17 @@ func NewClient(cfg *config.Config, tokenProvider apihelpers.ITokenProvider) *Cli
 		ParseError:          client.parseError,
 		HTTPTimeout:         cfg.HTTPTimeout,
 		RawResponseMaxBytes: cfg.AttachInlineMaxBytes,
+		MaxResponseBytes:    cfg.HTTPMaxResponseBytes,
+		Retry:               apihelpers.RetryPolicyFromConfig(cfg),
+		RateLimit:           cfg.TrackerRateLimit,
+		RateBurst:           cfg.TrackerRateBurst,
+		CircuitBreaker:      apihelpers.CircuitBreakerConfigFromConfig(cfg),
+		Cache: apihelpers.CacheConfigFromConfig(cfg, []apihelpers.CacheRule{
+			{PathPrefix: "/v3/queues", TTL: directoryCacheTTL},
+			{PathPrefix: "/v3/users", TTL: directoryCacheTTL},
+			{PathPrefix: "/v3/myself", TTL: directoryCacheTTL},
+		}),
+		Metrics: metrics,
 	})
 
 	return client
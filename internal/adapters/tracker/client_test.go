@@ -31,6 +31,7 @@ func TestClient_HeaderInjection(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	const (
 		testToken = "test-iam-token"
@@ -50,7 +51,7 @@ func TestClient_HeaderInjection(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return(testToken, nil)
 
-	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test only checks headers
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
@@ -66,6 +67,7 @@ func TestClient_HeaderInjection_POST(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	const (
 		testToken = "test-iam-token"
@@ -85,7 +87,7 @@ func TestClient_HeaderInjection_POST(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return(testToken, nil)
 
-	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test only checks headers
 	_, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{})
@@ -97,11 +99,46 @@ func TestClient_HeaderInjection_POST(t *testing.T) {
 	assert.Equal(t, "application/json", capturedHeaders.Get(apihelpers.HeaderContentType))
 }
 
+func TestClient_HeaderInjection_Yandex360Org(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
+
+	const testOrgID = "test-360-org-id"
+
+	var capturedHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		//nolint:errcheck,exhaustruct // test helper
+		json.NewEncoder(w).Encode(issueDTO{ID: "1", Key: "TEST-1"})
+	}))
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("test-token", nil)
+
+	cfg := newTestConfig(server.URL, testOrgID)
+	cfg.OrgType = config.OrgType360
+	client := NewClient(cfg, tokenProvider, nil, nil)
+
+	//nolint:exhaustruct // test only checks headers
+	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
+	require.NoError(t, err)
+
+	assert.Equal(t, testOrgID, capturedHeaders.Get(apihelpers.HeaderOrgID))
+	assert.Empty(t, capturedHeaders.Get(apihelpers.HeaderCloudOrgID))
+}
+
 func TestClient_Non2xx_ReturnsUpstreamError_Sanitized(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -113,7 +150,7 @@ func TestClient_Non2xx_ReturnsUpstreamError_Sanitized(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks error conversion
 	_, err := client.GetIssue(t.Context(), "TEST-999", domain.TrackerGetIssueOpts{})
@@ -134,6 +171,7 @@ func TestClient_Non2xx_FallbackMessage(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -145,7 +183,7 @@ func TestClient_Non2xx_FallbackMessage(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks fallback message
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
@@ -163,6 +201,7 @@ func TestClient_GetIssue_WithExpand(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -179,7 +218,7 @@ func TestClient_GetIssue_WithExpand(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	issue, err := client.GetIssue(t.Context(), "TEST-42", domain.TrackerGetIssueOpts{Expand: "attachments"})
 	require.NoError(t, err)
@@ -196,6 +235,7 @@ func TestClient_SearchIssues_StandardPagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -220,7 +260,7 @@ func TestClient_SearchIssues_StandardPagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test uses partial opts
 	result, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{
@@ -252,6 +292,7 @@ func TestClient_SearchIssues_ScrollPagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -271,7 +312,7 @@ func TestClient_SearchIssues_ScrollPagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test uses scroll pagination opts
 	result, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{
@@ -297,6 +338,7 @@ func TestClient_SearchIssues_ScrollPagination_SubsequentRequest(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -312,7 +354,7 @@ func TestClient_SearchIssues_ScrollPagination_SubsequentRequest(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test uses only scrollID
 	result, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{
@@ -331,6 +373,7 @@ func TestClient_CountIssues_WithFilter(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -349,7 +392,7 @@ func TestClient_CountIssues_WithFilter(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test uses only filter
 	count, err := client.CountIssues(t.Context(), domain.TrackerCountIssuesOpts{
@@ -368,6 +411,7 @@ func TestClient_CountIssues_WithQuery(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedBody map[string]any
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -381,7 +425,7 @@ func TestClient_CountIssues_WithQuery(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test uses only query
 	count, err := client.CountIssues(t.Context(), domain.TrackerCountIssuesOpts{
@@ -398,6 +442,7 @@ func TestClient_ListIssueTransitions(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -425,7 +470,7 @@ func TestClient_ListIssueTransitions(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	transitions, err := client.ListIssueTransitions(t.Context(), "TEST-42")
 	require.NoError(t, err)
@@ -444,6 +489,7 @@ func TestClient_ListQueues_WithPagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -465,7 +511,7 @@ func TestClient_ListQueues_WithPagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.ListQueues(t.Context(), domain.TrackerListQueuesOpts{
 		Expand:  "projects,team",
@@ -491,6 +537,7 @@ func TestClient_ListIssueComments_WithPagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -511,7 +558,7 @@ func TestClient_ListIssueComments_WithPagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.ListIssueComments(t.Context(), "TEST-1", domain.TrackerListCommentsOpts{
 		Expand:  "attachments",
@@ -537,6 +584,7 @@ func TestClient_GetIssueAttachment(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -553,7 +601,7 @@ func TestClient_GetIssueAttachment(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.GetIssueAttachment(t.Context(), "TEST-1", "4159", "attachment.txt")
 	require.NoError(t, err)
@@ -569,6 +617,7 @@ func TestClient_GetIssueAttachmentStream(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	payload := []byte("streamed payload")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -581,7 +630,7 @@ func TestClient_GetIssueAttachmentStream(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	stream, err := client.GetIssueAttachmentStream(t.Context(), "TEST-1", "4159", "attachment.txt")
 	require.NoError(t, err)
@@ -602,6 +651,7 @@ func TestClient_GetIssueAttachment_EnforcesMaxSize(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	payload := []byte("too-large")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -619,7 +669,7 @@ func TestClient_GetIssueAttachment_EnforcesMaxSize(t *testing.T) {
 		CloudOrgID:           "org",
 		AttachInlineMaxBytes: 4,
 	}
-	client := NewClient(cfg, tokenProvider)
+	client := NewClient(cfg, tokenProvider, nil, nil)
 
 	_, err := client.GetIssueAttachment(t.Context(), "TEST-1", "4159", "attachment.txt")
 	require.Error(t, err)
@@ -631,6 +681,7 @@ func TestClient_GetIssueAttachmentPreview(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	var capturedMethod string
@@ -647,7 +698,7 @@ func TestClient_GetIssueAttachmentPreview(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.GetIssueAttachmentPreview(t.Context(), "TEST-1", "4159")
 	require.NoError(t, err)
@@ -662,6 +713,7 @@ func TestClient_GetIssueAttachmentPreviewStream(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	payload := []byte{0x1, 0x2, 0x3}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -674,7 +726,7 @@ func TestClient_GetIssueAttachmentPreviewStream(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	stream, err := client.GetIssueAttachmentPreviewStream(t.Context(), "TEST-1", "4159")
 	require.NoError(t, err)
@@ -694,6 +746,7 @@ func TestClient_UpstreamError_NoTokenLeak(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	const secretToken = "super-secret-iam-token-that-must-not-leak"
 
@@ -707,7 +760,7 @@ func TestClient_UpstreamError_NoTokenLeak(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return(secretToken, nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks token leak
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
@@ -723,6 +776,7 @@ func TestClient_ErrorCodes_401(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var requestCount atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -736,7 +790,7 @@ func TestClient_ErrorCodes_401(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil).Times(2)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks 401 handling
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
@@ -753,6 +807,7 @@ func TestClient_ErrorCodes_403(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusForbidden)
@@ -764,7 +819,7 @@ func TestClient_ErrorCodes_403(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil).Times(2)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks 403 handling
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
@@ -781,6 +836,7 @@ func TestClient_ErrorCodes_404(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -792,7 +848,7 @@ func TestClient_ErrorCodes_404(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks 404 handling
 	_, err := client.GetIssue(t.Context(), "TEST-999", domain.TrackerGetIssueOpts{})
@@ -809,6 +865,7 @@ func TestClient_ErrorCodes_422(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusUnprocessableEntity)
@@ -820,7 +877,7 @@ func TestClient_ErrorCodes_422(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks 422 handling
 	_, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{Query: "invalid"})
@@ -837,6 +894,7 @@ func TestClient_ErrorCodes_429(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusTooManyRequests)
@@ -848,7 +906,7 @@ func TestClient_ErrorCodes_429(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks 429 handling
 	_, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{})
@@ -865,6 +923,7 @@ func TestClient_IssueID_PathEscaping(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedRawURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -879,7 +938,7 @@ func TestClient_IssueID_PathEscaping(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks path escaping
 	_, err := client.GetIssue(t.Context(), "TEST/SPECIAL-1", domain.TrackerGetIssueOpts{})
@@ -894,6 +953,7 @@ func TestClient_SearchIssues_QueryLanguage(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedBody map[string]any
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -908,7 +968,7 @@ func TestClient_SearchIssues_QueryLanguage(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks query language
 	_, err := client.SearchIssues(t.Context(), domain.TrackerSearchIssuesOpts{
@@ -924,6 +984,7 @@ func TestClient_ErrorResponse_WithErrorsArray(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -935,7 +996,7 @@ func TestClient_ErrorResponse_WithErrorsArray(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	//nolint:exhaustruct // test checks error array
 	_, err := client.GetIssue(t.Context(), "TEST-1", domain.TrackerGetIssueOpts{})
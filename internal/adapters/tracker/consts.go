@@ -1,5 +1,7 @@
 package tracker
 
+import "time"
+
 const (
 	headerAcceptLanguage = "Accept-Language"
 	headerXTotalCount    = "X-Total-Count"
@@ -10,3 +12,6 @@ const (
 
 	acceptLangEN = "en"
 )
+
+// directoryCacheTTL is how long queue and user responses are cached; they rarely change.
+const directoryCacheTTL = 10 * time.Minute
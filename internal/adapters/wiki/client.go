@@ -12,6 +12,7 @@ import (
 
 	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
 	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/doctor"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	wikitools "github.com/n-r-w/yandex-mcp/internal/tools/wiki"
 )
@@ -21,25 +22,45 @@ type Client struct {
 	apiClient *apihelpers.APIClient
 }
 
-// Compile-time check that Client implements the tools interface.
-var _ wikitools.IWikiAdapter = (*Client)(nil)
+// Compile-time checks that Client implements the consumer interfaces.
+var (
+	_ wikitools.IWikiAdapter = (*Client)(nil)
+	_ doctor.IWikiClient     = (*Client)(nil)
+)
 
 // NewClient creates a new Wiki API client.
-func NewClient(cfg *config.Config, tokenProvider apihelpers.ITokenProvider) *Client {
+// A nil httpClient uses a default client with the configured timeout; nil metrics disable request metrics.
+func NewClient(
+	cfg *config.Config,
+	tokenProvider apihelpers.ITokenProvider,
+	httpClient *http.Client,
+	metrics apihelpers.IMetrics,
+) *Client {
 	client := &Client{
 		apiClient: nil, // set below
 	}
 
 	client.apiClient = apihelpers.NewAPIClient(apihelpers.APIClientConfig{
-		HTTPClient:          nil, // uses default
+		HTTPClient:          httpClient,
 		TokenProvider:       tokenProvider,
 		BaseURL:             strings.TrimSuffix(cfg.WikiBaseURL, "/"),
 		OrgID:               cfg.CloudOrgID,
+		OrgIDHeader:         apihelpers.OrgIDHeader(cfg.OrgType),
 		ExtraHeaders:        nil,
 		ServiceName:         string(domain.ServiceWiki),
 		ParseError:          client.parseError,
 		HTTPTimeout:         cfg.HTTPTimeout,
 		RawResponseMaxBytes: cfg.AttachInlineMaxBytes,
+		MaxResponseBytes:    cfg.HTTPMaxResponseBytes,
+		Retry:               apihelpers.RetryPolicyFromConfig(cfg),
+		RateLimit:           cfg.WikiRateLimit,
+		RateBurst:           cfg.WikiRateBurst,
+		CircuitBreaker:      apihelpers.CircuitBreakerConfigFromConfig(cfg),
+		Cache: apihelpers.CacheConfigFromConfig(cfg, []apihelpers.CacheRule{
+			{PathPrefix: "/v1/pages", TTL: pageCacheTTL},
+			{PathPrefix: "/v1/grids", TTL: pageCacheTTL},
+		}),
+		Metrics: metrics,
 	})
 
 	return client
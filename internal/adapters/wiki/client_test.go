@@ -31,6 +31,7 @@ func TestClient_HeaderInjection(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	const (
 		testToken = "test-iam-token"
@@ -50,7 +51,7 @@ func TestClient_HeaderInjection(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return(testToken, nil)
 
-	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, testOrgID), tokenProvider, nil, nil)
 
 	_, err := client.GetPageBySlug(t.Context(), "test/page", domain.WikiGetPageOpts{})
 	require.NoError(t, err)
@@ -65,6 +66,7 @@ func TestClient_Non2xx_ReturnsUpstreamError_Sanitized(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -76,7 +78,7 @@ func TestClient_Non2xx_ReturnsUpstreamError_Sanitized(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	_, err := client.GetPageByID(t.Context(), "123", domain.WikiGetPageOpts{})
 	require.Error(t, err)
@@ -97,6 +99,7 @@ func TestClient_Non2xx_FallbackMessage(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -108,7 +111,7 @@ func TestClient_Non2xx_FallbackMessage(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	_, err := client.GetPageBySlug(t.Context(), "test/page", domain.WikiGetPageOpts{})
 	require.Error(t, err)
@@ -125,6 +128,7 @@ func TestClient_GetPageBySlug_Fields(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -139,7 +143,7 @@ func TestClient_GetPageBySlug_Fields(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	page, err := client.GetPageBySlug(t.Context(), "test/page", domain.WikiGetPageOpts{Fields: []string{"content", "attributes"}})
 	require.NoError(t, err)
@@ -154,6 +158,7 @@ func TestClient_ListPageResources_Pagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -175,7 +180,7 @@ func TestClient_ListPageResources_Pagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.ListPageResources(t.Context(), "42", domain.WikiListResourcesOpts{
 		Cursor:         "start-cursor",
@@ -206,6 +211,7 @@ func TestClient_ListPageResources_EnforcesMaxPageSize(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -224,7 +230,7 @@ func TestClient_ListPageResources_EnforcesMaxPageSize(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	_, err := client.ListPageResources(t.Context(), "1", domain.WikiListResourcesOpts{
 		Cursor:         "",
@@ -244,6 +250,7 @@ func TestClient_ListPageResources_ResourceUnionMapping(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -291,7 +298,7 @@ func TestClient_ListPageResources_ResourceUnionMapping(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.ListPageResources(t.Context(), "1", domain.WikiListResourcesOpts{
 		Cursor:         "",
@@ -345,6 +352,7 @@ func TestClient_ListPageGrids_Pagination(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -366,7 +374,7 @@ func TestClient_ListPageGrids_Pagination(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	result, err := client.ListPageGrids(t.Context(), "99", domain.WikiListGridsOpts{
 		Cursor:         "",
@@ -391,6 +399,7 @@ func TestClient_GetGridByID_WithOptions(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -408,7 +417,7 @@ func TestClient_GetGridByID_WithOptions(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	grid, err := client.GetGridByID(t.Context(), "abc-123", domain.WikiGetGridOpts{
 		Fields:   []string{"attributes", "user_permissions"},
@@ -437,6 +446,7 @@ func TestClient_GetPageByID_Success(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	var capturedURL string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -457,7 +467,7 @@ func TestClient_GetPageByID_Success(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return("token", nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	page, err := client.GetPageByID(t.Context(), "42", domain.WikiGetPageOpts{Fields: []string{"content"}})
 	require.NoError(t, err)
@@ -474,6 +484,7 @@ func TestClient_UpstreamError_NoTokenLeak(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	const secretToken = "super-secret-iam-token-that-must-not-leak"
 
@@ -487,7 +498,7 @@ func TestClient_UpstreamError_NoTokenLeak(t *testing.T) {
 
 	tokenProvider.EXPECT().Token(gomock.Any(), gomock.Any()).Return(secretToken, nil)
 
-	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider)
+	client := NewClient(newTestConfig(server.URL, "org"), tokenProvider, nil, nil)
 
 	_, err := client.GetPageBySlug(t.Context(), "test/page", domain.WikiGetPageOpts{})
 	require.Error(t, err)
@@ -502,6 +513,7 @@ func TestClient_FullConfig(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	tokenProvider := apihelpers.NewMockITokenProvider(ctrl)
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -522,7 +534,7 @@ func TestClient_FullConfig(t *testing.T) {
 		IAMTokenRefreshPeriod: 10 * time.Hour,
 		AttachInlineMaxBytes:  testAttachInlineMaxBytes,
 	}
-	client := NewClient(cfg, tokenProvider)
+	client := NewClient(cfg, tokenProvider, nil, nil)
 
 	_, err := client.GetPageBySlug(t.Context(), "test/page", domain.WikiGetPageOpts{})
 	require.NoError(t, err)
@@ -1,6 +1,11 @@
 package wiki
 
+import "time"
+
 const (
 	maxResourcesSize = 50
 	maxGridsSize     = 50
 )
+
+// pageCacheTTL is how long page and grid responses are cached; ETag revalidation keeps refetches cheap.
+const pageCacheTTL = time.Minute
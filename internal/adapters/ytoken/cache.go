@@ -0,0 +1,184 @@
+package ytoken
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/singleflight/v2"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// issuedToken is a token together with the moment it must no longer be served from cache.
+type issuedToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+// tokenFetchFunc obtains a fresh token from the underlying credential source.
+type tokenFetchFunc func(ctx context.Context) (issuedToken, error)
+
+// tokenCache caches a single token and coordinates refreshes with single-flight behavior.
+type tokenCache struct {
+	fetch   tokenFetchFunc
+	nowFunc func() time.Time
+	// store persists the token across restarts; nil disables persistence.
+	store *tokenStore
+	// metrics records refreshes labeled with org and source; nil disables metrics.
+	metrics ITokenMetrics
+	org     string
+	source  string
+
+	mu          sync.RWMutex
+	cached      issuedToken
+	refreshedAt time.Time
+
+	// single-flight group for token refresh operations
+	sf singleflight.Group[string, string]
+}
+
+// newTokenCache creates a token cache backed by the given fetch function.
+func newTokenCache(fetch tokenFetchFunc) *tokenCache {
+	//nolint:exhaustruct // cache and sync fields intentionally start with zero values
+	return &tokenCache{
+		fetch:   fetch,
+		nowFunc: time.Now,
+	}
+}
+
+// now returns the current time using the configured clock.
+func (c *tokenCache) now() time.Time {
+	return c.nowFunc()
+}
+
+// persistTo enables on-disk persistence and restores a previously persisted token that is still valid.
+// Not thread-safe; call before use.
+func (c *tokenCache) persistTo(store *tokenStore) {
+	c.store = store
+
+	persisted, ok, err := store.load()
+	if err != nil {
+		slog.Warn("failed to load persisted token", slog.String("error", err.Error()))
+		return
+	}
+	if !ok || !c.now().Before(persisted.ExpiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	c.cached = issuedToken{value: persisted.Token, expiresAt: persisted.ExpiresAt}
+	c.refreshedAt = persisted.RefreshedAt
+	c.mu.Unlock()
+}
+
+// observeWith enables refresh metrics labeled with the organization profile and credential source.
+// Not thread-safe; call before use.
+func (c *tokenCache) observeWith(metrics ITokenMetrics, org string, source config.AuthSource) {
+	c.metrics = metrics
+	c.org = org
+	c.source = string(source)
+}
+
+// token returns a cached token or fetches a new one if cache is stale or refresh is forced.
+// A forced refresh means the cached token was rejected, so it is dropped from memory and disk first.
+func (c *tokenCache) token(ctx context.Context, forceRefresh bool) (string, error) {
+	if forceRefresh {
+		c.invalidate(ctx)
+		return c.refresh(ctx)
+	}
+
+	// Fast path: check if cached token is still valid
+	if token, ok := c.get(); ok {
+		return token, nil
+	}
+
+	return c.refresh(ctx)
+}
+
+// get returns the cached token if it exists and is not expired.
+func (c *tokenCache) get() (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.cached.value == "" {
+		return "", false
+	}
+
+	if !c.now().Before(c.cached.expiresAt) {
+		return "", false
+	}
+
+	return c.cached.value, true
+}
+
+// refresh fetches a new token with single-flight coordination.
+func (c *tokenCache) refresh(ctx context.Context) (string, error) {
+	// Use singleflight to ensure only one refresh happens at a time
+	result, _, err := c.sf.Do(ctx, tokenRefreshRequestKey, c.doRefresh)
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// doRefresh performs the actual token refresh and updates the cache.
+func (c *tokenCache) doRefresh(ctx context.Context) (string, error) {
+	token, err := c.fetch(ctx)
+	if c.metrics != nil {
+		c.metrics.ObserveTokenRefresh(c.org, c.source, err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	refreshedAt := c.now()
+
+	c.mu.Lock()
+	c.cached = token
+	c.refreshedAt = refreshedAt
+	c.mu.Unlock()
+
+	if c.store != nil {
+		persisted := persistedToken{Token: token.value, ExpiresAt: token.expiresAt, RefreshedAt: refreshedAt}
+		if err := c.store.save(persisted); err != nil {
+			slog.WarnContext(ctx, "failed to persist token", slog.String("error", err.Error()))
+		}
+	}
+
+	return token.value, nil
+}
+
+// invalidate drops the cached token from memory and from the on-disk cache.
+func (c *tokenCache) invalidate(ctx context.Context) {
+	c.mu.Lock()
+	c.cached = issuedToken{}
+	c.mu.Unlock()
+
+	if c.store != nil {
+		if err := c.store.remove(); err != nil {
+			slog.WarnContext(ctx, "failed to remove persisted token", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// cacheExpiry returns when a token issued at now should stop being served from cache.
+// It honors the upstream expiry minus a safety margin and never exceeds the refresh period.
+func cacheExpiry(now, upstreamExpiry time.Time, refreshPeriod time.Duration) time.Time {
+	expiresAt := now.Add(refreshPeriod)
+	if upstreamExpiry.IsZero() {
+		return expiresAt
+	}
+
+	margin := tokenExpiryMargin
+	if lifetime := upstreamExpiry.Sub(now); lifetime < 2*margin {
+		margin = lifetime / 2
+	}
+
+	if upstream := upstreamExpiry.Add(-margin); upstream.Before(expiresAt) {
+		return upstream
+	}
+
+	return expiresAt
+}
@@ -0,0 +1,45 @@
+package ytoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+func TestTokenCache_RecordsRefreshMetrics(t *testing.T) {
+	t.Parallel()
+
+	errFetch := errors.New("metadata service unavailable")
+	fail := true
+	cache := newTokenCache(func(context.Context) (issuedToken, error) {
+		if fail {
+			return issuedToken{}, errFetch
+		}
+		return issuedToken{value: "token", expiresAt: time.Now().Add(time.Hour)}, nil
+	})
+
+	metrics := NewMockITokenMetrics(gomock.NewController(t))
+	cache.observeWith(metrics, "acme", config.AuthSourceMetadata)
+
+	gomock.InOrder(
+		metrics.EXPECT().ObserveTokenRefresh("acme", string(config.AuthSourceMetadata), errFetch),
+		metrics.EXPECT().ObserveTokenRefresh("acme", string(config.AuthSourceMetadata), nil),
+	)
+
+	_, err := cache.token(t.Context(), false)
+	require.ErrorIs(t, err, errFetch)
+
+	fail = false
+	_, err = cache.token(t.Context(), false)
+	require.NoError(t, err)
+
+	// a cached token is served without a refresh
+	_, err = cache.token(t.Context(), false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,369 @@
+package ytoken
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/singleflight/v2"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/doctor"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/n-r-w/yandex-mcp/internal/pause"
+	diagnosticstools "github.com/n-r-w/yandex-mcp/internal/tools/diagnostics"
+)
+
+// chainLink is a single credential source tried by ChainProvider.
+type chainLink struct {
+	source config.AuthSource
+	// provider is nil when the source is not configured or could not be constructed.
+	provider apihelpers.ITokenProvider
+	// unavailable explains why provider is nil.
+	unavailable error
+	// skipped marks sources that are simply not configured, as opposed to broken ones.
+	skipped bool
+	// probeTimeout bounds the first token acquisition during resolution (zero means no limit).
+	probeTimeout time.Duration
+	// startRefresher optionally starts background renewal once the link is selected.
+	startRefresher func(ctx context.Context)
+}
+
+// ChainProvider implements ITokenProvider by trying credential sources in order
+// and remembering the first one that succeeds.
+type ChainProvider struct {
+	method  config.AuthMethod
+	links   []chainLink
+	nowFunc func() time.Time
+
+	mu          sync.RWMutex
+	selected    int
+	diagnostics domain.AuthDiagnostics
+
+	// single-flight group for chain resolution
+	sf singleflight.Group[string, int]
+}
+
+// Compile-time interface assertions.
+var (
+	_ apihelpers.ITokenProvider                 = (*ChainProvider)(nil)
+	_ diagnosticstools.IAuthDiagnosticsProvider = (*ChainProvider)(nil)
+	_ doctor.ITokenProvider                     = (*ChainProvider)(nil)
+)
+
+// NewChainProvider creates a token provider for the configured credential sources.
+// Single-source authentication methods produce a chain with one link.
+// Token refreshes are recorded in metrics unless it is nil.
+func NewChainProvider(cfg *config.Config, metrics ITokenMetrics) (*ChainProvider, error) {
+	links := make([]chainLink, 0, len(cfg.AuthChain))
+	for _, source := range cfg.AuthChain {
+		links = append(links, newChainLink(cfg, source, len(cfg.AuthChain) > 1, metrics))
+	}
+
+	provider := newChainProvider(cfg.AuthMethod, links)
+
+	usable := false
+	for _, link := range links {
+		if link.provider != nil {
+			usable = true
+			break
+		}
+	}
+	if !usable {
+		return nil, fmt.Errorf("%w: %s", errNoAuthSource, describeAttempts(provider.unavailableAttempts()))
+	}
+
+	return provider, nil
+}
+
+// newChainProvider creates a chain provider from prepared links.
+func newChainProvider(method config.AuthMethod, links []chainLink) *ChainProvider {
+	chain := make([]string, 0, len(links))
+	for _, link := range links {
+		chain = append(chain, string(link.source))
+	}
+
+	//nolint:exhaustruct // sync fields intentionally start with zero values
+	return &ChainProvider{
+		method:   method,
+		links:    links,
+		nowFunc:  time.Now,
+		selected: -1,
+		diagnostics: domain.AuthDiagnostics{
+			Method:         string(method),
+			Chain:          chain,
+			SelectedSource: "",
+			ResolvedAt:     time.Time{},
+			Attempts:       nil,
+		},
+	}
+}
+
+// newChainLink constructs the provider for a single credential source.
+func newChainLink(cfg *config.Config, source config.AuthSource, inChain bool, metrics ITokenMetrics) chainLink {
+	link := chainLink{
+		source:         source,
+		provider:       nil,
+		unavailable:    nil,
+		skipped:        false,
+		probeTimeout:   0,
+		startRefresher: nil,
+	}
+
+	notConfigured := func(envName string) chainLink {
+		link.skipped = true
+		link.unavailable = fmt.Errorf("%s is not set", envName)
+		return link
+	}
+
+	switch source {
+	case config.AuthSourceOAuthToken:
+		if cfg.OAuthToken == "" {
+			return notConfigured("YANDEX_OAUTH_TOKEN")
+		}
+		link.provider = newOAuthProvider(cfg.OAuthToken, "")
+	case config.AuthSourceOAuthTokenFile:
+		if cfg.OAuthTokenFile == "" {
+			return notConfigured("YANDEX_OAUTH_TOKEN_FILE")
+		}
+		link.provider = newOAuthProvider("", cfg.OAuthTokenFile)
+	case config.AuthSourceServiceAccount:
+		if cfg.ServiceAccountKeyFile == "" {
+			return notConfigured("YANDEX_SA_KEY_FILE")
+		}
+		provider, err := NewServiceAccountProvider(cfg)
+		if err != nil {
+			link.unavailable = err
+			return link
+		}
+		provider.cache.observeWith(metrics, cfg.OrgName, source)
+		link.provider = provider
+		link.startRefresher = provider.StartRefresher
+	case config.AuthSourceMetadata:
+		provider := NewMetadataProvider(cfg)
+		provider.cache.observeWith(metrics, cfg.OrgName, source)
+		link.provider = provider
+		link.startRefresher = provider.StartRefresher
+		if inChain {
+			// Outside of a cloud VM the metadata address is usually unroutable; do not stall the chain.
+			link.probeTimeout = metadataProbeTimeout
+		}
+	case config.AuthSourceYC:
+		provider := NewProvider(cfg)
+		provider.cache.observeWith(metrics, cfg.OrgName, source)
+		link.provider = provider
+		link.startRefresher = provider.StartRefresher
+	default:
+		link.unavailable = fmt.Errorf("unsupported auth source %q", source)
+	}
+
+	return link
+}
+
+// Start resolves the chain in the background, retrying with backoff until a source succeeds,
+// and then starts background renewal for the selected source. It stops when ctx is done.
+func (c *ChainProvider) Start(ctx context.Context) {
+	go func() {
+		backoff := refreshBackoffMin
+		for {
+			link, err := c.resolve(ctx)
+			if err == nil {
+				if link.startRefresher != nil {
+					link.startRefresher(ctx)
+				}
+				return
+			}
+
+			if !pause.Sleep(ctx, pause.Jitter(backoff)) {
+				return
+			}
+			backoff = min(backoff*2, refreshBackoffMax)
+		}
+	}()
+}
+
+// AuthScheme returns the Authorization header scheme of the selected source.
+func (c *ChainProvider) AuthScheme() string {
+	if link := c.selectedLink(); link != nil {
+		return link.provider.AuthScheme()
+	}
+	return apihelpers.AuthSchemeBearer
+}
+
+// Token returns a token from the selected source, resolving the chain first if needed.
+func (c *ChainProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	link, err := c.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return link.provider.Token(ctx, forceRefresh)
+}
+
+// AuthDiagnostics returns a snapshot of how credentials were resolved.
+func (c *ChainProvider) AuthDiagnostics() domain.AuthDiagnostics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := c.diagnostics
+	result.Chain = append([]string(nil), c.diagnostics.Chain...)
+	result.Attempts = append([]domain.AuthSourceAttempt(nil), c.diagnostics.Attempts...)
+
+	return result
+}
+
+// selectedIndex returns the index of the remembered source or -1 if the chain is not resolved yet.
+func (c *ChainProvider) selectedIndex() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.selected
+}
+
+// selectedLink returns the remembered source or nil if the chain is not resolved yet.
+func (c *ChainProvider) selectedLink() *chainLink {
+	if idx := c.selectedIndex(); idx >= 0 {
+		return &c.links[idx]
+	}
+	return nil
+}
+
+// resolve returns the remembered source or tries the chain with single-flight coordination.
+// Failed resolutions are not remembered, so later calls try the chain again.
+func (c *ChainProvider) resolve(ctx context.Context) (*chainLink, error) {
+	if link := c.selectedLink(); link != nil {
+		return link, nil
+	}
+
+	idx, _, err := c.sf.Do(ctx, chainResolveRequestKey, c.doResolve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c.links[idx], nil
+}
+
+// doResolve tries each link in order and records the outcome of every attempt.
+func (c *ChainProvider) doResolve(ctx context.Context) (int, error) {
+	if idx := c.selectedIndex(); idx >= 0 {
+		return idx, nil
+	}
+
+	attempts := make([]domain.AuthSourceAttempt, 0, len(c.links))
+	for i := range c.links {
+		link := &c.links[i]
+
+		if link.provider == nil {
+			attempts = append(attempts, unavailableAttempt(link))
+			continue
+		}
+
+		if err := probeLink(ctx, link); err != nil {
+			if ctx.Err() != nil {
+				return -1, fmt.Errorf("token fetch canceled or timed out: %w", ctx.Err())
+			}
+			attempts = append(attempts, domain.AuthSourceAttempt{
+				Source:    string(link.source),
+				Succeeded: false,
+				Skipped:   false,
+				Reason:    sanitizeReason(err),
+			})
+			continue
+		}
+
+		attempts = append(attempts, domain.AuthSourceAttempt{
+			Source:    string(link.source),
+			Succeeded: true,
+			Skipped:   false,
+			Reason:    "",
+		})
+		c.record(i, attempts)
+
+		slog.InfoContext(ctx, "auth source selected",
+			slog.String("method", string(c.method)),
+			slog.String("source", string(link.source)),
+			slog.Any("earlier_sources", attemptsLogValue(attempts[:len(attempts)-1])),
+		)
+
+		return i, nil
+	}
+
+	c.record(-1, attempts)
+
+	return -1, logError(ctx, fmt.Errorf("%w: %s", errNoAuthSource, describeAttempts(attempts)))
+}
+
+// record stores the resolution outcome for diagnostics.
+func (c *ChainProvider) record(selected int, attempts []domain.AuthSourceAttempt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.selected = selected
+	c.diagnostics.Attempts = attempts
+	c.diagnostics.ResolvedAt = c.nowFunc()
+	if selected >= 0 {
+		c.diagnostics.SelectedSource = string(c.links[selected].source)
+	}
+}
+
+// unavailableAttempts describes links that could not be constructed.
+func (c *ChainProvider) unavailableAttempts() []domain.AuthSourceAttempt {
+	attempts := make([]domain.AuthSourceAttempt, 0, len(c.links))
+	for i := range c.links {
+		if c.links[i].provider == nil {
+			attempts = append(attempts, unavailableAttempt(&c.links[i]))
+		}
+	}
+	return attempts
+}
+
+// probeLink acquires the first token from a link, bounded by its probe timeout.
+func probeLink(ctx context.Context, link *chainLink) error {
+	if link.probeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, link.probeTimeout)
+		defer cancel()
+	}
+
+	_, err := link.provider.Token(ctx, false)
+	return err
+}
+
+// unavailableAttempt describes a link without a provider.
+func unavailableAttempt(link *chainLink) domain.AuthSourceAttempt {
+	return domain.AuthSourceAttempt{
+		Source:    string(link.source),
+		Succeeded: false,
+		Skipped:   link.skipped,
+		Reason:    sanitizeReason(link.unavailable),
+	}
+}
+
+// sanitizeReason converts an error into a short reason safe for logs and tool output.
+func sanitizeReason(err error) string {
+	if err == nil {
+		return ""
+	}
+	return domain.SanitizeBody(err.Error(), maxAuthReasonBytes)
+}
+
+// describeAttempts renders failed attempts as "source: reason" pairs.
+func describeAttempts(attempts []domain.AuthSourceAttempt) string {
+	parts := make([]string, 0, len(attempts))
+	for _, attempt := range attempts {
+		parts = append(parts, attempt.Source+": "+attempt.Reason)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// attemptsLogValue groups attempt reasons by source for structured logging.
+func attemptsLogValue(attempts []domain.AuthSourceAttempt) slog.Value {
+	attrs := make([]slog.Attr, 0, len(attempts))
+	for _, attempt := range attempts {
+		attrs = append(attrs, slog.String(attempt.Source, attempt.Reason))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,145 @@
+package ytoken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// testChainLink creates a chain link backed by the given provider.
+func testChainLink(source config.AuthSource, provider apihelpers.ITokenProvider) chainLink {
+	return chainLink{
+		source:         source,
+		provider:       provider,
+		unavailable:    nil,
+		skipped:        false,
+		probeTimeout:   0,
+		startRefresher: nil,
+	}
+}
+
+func TestChainProvider_SelectsFirstWorkingSourceAndRemembersIt(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	failing := apihelpers.NewMockITokenProvider(ctrl)
+	working := apihelpers.NewMockITokenProvider(ctrl)
+	unused := apihelpers.NewMockITokenProvider(ctrl)
+
+	failing.EXPECT().Token(gomock.Any(), false).Return("", errors.New("yc not found")).Times(1)
+	working.EXPECT().Token(gomock.Any(), false).Return("oauth-token", nil).Times(3)
+	working.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeOAuth).AnyTimes()
+
+	skipped := testChainLink(config.AuthSourceOAuthToken, nil)
+	skipped.skipped = true
+	skipped.unavailable = errors.New("YANDEX_OAUTH_TOKEN is not set")
+
+	provider := newChainProvider(config.AuthMethodChain, []chainLink{
+		skipped,
+		testChainLink(config.AuthSourceYC, failing),
+		testChainLink(config.AuthSourceOAuthTokenFile, working),
+		testChainLink(config.AuthSourceMetadata, unused),
+	})
+
+	ctx := t.Context()
+
+	// First call resolves the chain (probe + token); the second uses the remembered source.
+	token, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "oauth-token", token)
+
+	token, err = provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "oauth-token", token)
+	assert.Equal(t, apihelpers.AuthSchemeOAuth, provider.AuthScheme())
+
+	diag := provider.AuthDiagnostics()
+	assert.Equal(t, "chain", diag.Method)
+	assert.Equal(t, []string{"oauth_token", "yc", "oauth_token_file", "metadata"}, diag.Chain)
+	assert.Equal(t, "oauth_token_file", diag.SelectedSource)
+	assert.False(t, diag.ResolvedAt.IsZero())
+	require.Len(t, diag.Attempts, 3)
+	assert.True(t, diag.Attempts[0].Skipped)
+	assert.Equal(t, "YANDEX_OAUTH_TOKEN is not set", diag.Attempts[0].Reason)
+	assert.False(t, diag.Attempts[1].Succeeded)
+	assert.Equal(t, "yc not found", diag.Attempts[1].Reason)
+	assert.True(t, diag.Attempts[2].Succeeded)
+}
+
+func TestChainProvider_AllSourcesFailAndResolutionIsRetried(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	source := apihelpers.NewMockITokenProvider(ctrl)
+	gomock.InOrder(
+		source.EXPECT().Token(gomock.Any(), false).Return("", errors.New("not logged in")),
+		source.EXPECT().Token(gomock.Any(), false).Return("iam-token", nil),
+		source.EXPECT().Token(gomock.Any(), false).Return("iam-token", nil),
+	)
+
+	provider := newChainProvider(config.AuthMethodYC, []chainLink{testChainLink(config.AuthSourceYC, source)})
+
+	ctx := t.Context()
+
+	_, err := provider.Token(ctx, false)
+	require.Error(t, err)
+	require.ErrorIs(t, err, errNoAuthSource)
+	assert.Contains(t, err.Error(), "yc: not logged in")
+	assert.Empty(t, provider.AuthDiagnostics().SelectedSource)
+
+	token, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "iam-token", token)
+	assert.Equal(t, "yc", provider.AuthDiagnostics().SelectedSource)
+}
+
+func TestChainProvider_ProbeTimeoutMovesToNextSource(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	slow := apihelpers.NewMockITokenProvider(ctrl)
+	fast := apihelpers.NewMockITokenProvider(ctrl)
+
+	slow.EXPECT().Token(gomock.Any(), false).DoAndReturn(func(ctx context.Context, _ bool) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	fast.EXPECT().Token(gomock.Any(), false).Return("iam-token", nil).Times(2)
+
+	metadataLink := testChainLink(config.AuthSourceMetadata, slow)
+	metadataLink.probeTimeout = 10 * time.Millisecond
+
+	provider := newChainProvider(config.AuthMethodChain, []chainLink{
+		metadataLink,
+		testChainLink(config.AuthSourceYC, fast),
+	})
+
+	token, err := provider.Token(t.Context(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "iam-token", token)
+	assert.Equal(t, "yc", provider.AuthDiagnostics().SelectedSource)
+}
+
+func TestNewChainProvider_FailsWithoutUsableSource(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // only auth fields relevant for chain construction
+	cfg := &config.Config{
+		AuthMethod: config.AuthMethodChain,
+		AuthChain:  []config.AuthSource{config.AuthSourceOAuthToken, config.AuthSourceServiceAccount},
+	}
+
+	_, err := NewChainProvider(cfg, nil)
+	require.Error(t, err)
+	require.ErrorIs(t, err, errNoAuthSource)
+	assert.Contains(t, err.Error(), "YANDEX_OAUTH_TOKEN is not set")
+	assert.Contains(t, err.Error(), "YANDEX_SA_KEY_FILE is not set")
+}
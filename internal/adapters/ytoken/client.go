@@ -1,31 +1,31 @@
-// Package ytoken provides IAM token acquisition using the yc CLI.
+// Package ytoken provides token acquisition for Yandex API authentication.
 package ytoken
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"regexp"
-	"sync"
 	"time"
 
-	"github.com/n-r-w/singleflight/v2"
 	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
 	"github.com/n-r-w/yandex-mcp/internal/config"
 )
 
+// ycTokenOutput is the `yc iam create-token --format json` output.
+type ycTokenOutput struct {
+	IAMToken  string    `json:"iam_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // Provider implements ITokenProvider with caching and single-flight behavior.
 type Provider struct {
 	executor      ICommandExecutor
 	refreshPeriod time.Duration
-	nowFunc       func() time.Time
-
-	mu          sync.RWMutex
-	cachedToken string
-	refreshedAt time.Time
-
-	// single-flight group for token refresh operations
-	sf singleflight.Group[string, string]
+	cache         *tokenCache
 
 	tokenRegex *regexp.Regexp
 }
@@ -34,19 +34,29 @@ type Provider struct {
 var _ apihelpers.ITokenProvider = (*Provider)(nil)
 
 // NewProvider creates a new token provider.
+// When the on-disk token cache is enabled, a token persisted by a previous run is reused while still valid.
 func NewProvider(cfg *config.Config) *Provider {
-	//nolint:exhaustruct // cache and sync fields intentionally start with zero values
-	return &Provider{
-		executor:      newCommandExecutor(),
+	p := &Provider{
+		executor:      newCommandExecutor(cfg.YCPath, cfg.YCProfile, cfg.YCTimeout),
 		refreshPeriod: cfg.IAMTokenRefreshPeriod,
-		nowFunc:       time.Now,
+		cache:         nil, // set below
 		tokenRegex:    regexp.MustCompile(tokenRegexPattern),
 	}
+	p.cache = newTokenCache(p.fetchToken)
+
+	store, err := newYCTokenStore(cfg)
+	if err != nil {
+		slog.Warn("on-disk token cache is unavailable", slog.String("error", err.Error()))
+	} else if store != nil {
+		p.cache.persistTo(store)
+	}
+
+	return p
 }
 
 // setNowFunc sets the time function for testing. Not thread-safe; call before use.
 func (p *Provider) setNowFunc(fn func() time.Time) {
-	p.nowFunc = fn
+	p.cache.nowFunc = fn
 }
 
 // setExecutor sets the command executor for testing. Not thread-safe; call before use.
@@ -54,82 +64,75 @@ func (p *Provider) setExecutor(exec ICommandExecutor) {
 	p.executor = exec
 }
 
-// Token returns a cached IAM token or fetches a new one if cache is stale.
-func (p *Provider) Token(ctx context.Context, forceRefresh bool) (string, error) {
-	if !forceRefresh {
-		// Fast path: check if cached token is still valid
-		if token, ok := p.getCachedToken(); ok {
-			return token, nil
-		}
-	}
-
-	return p.refreshToken(ctx)
+// StartRefresher starts renewing the token in the background ahead of its expiry until ctx is done.
+// Call it once after construction; Token keeps working without it, refreshing on demand.
+func (p *Provider) StartRefresher(ctx context.Context) {
+	p.cache.startRefresher(ctx)
 }
 
-// getCachedToken returns the cached token if it exists and is not expired.
-func (p *Provider) getCachedToken() (string, bool) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-
-	if p.cachedToken == "" {
-		return "", false
-	}
-
-	tokenExpired := p.nowFunc().Sub(p.refreshedAt) >= p.refreshPeriod
-	if tokenExpired {
-		return "", false
-	}
-
-	return p.cachedToken, true
+// AuthScheme returns the Authorization header scheme for IAM tokens.
+func (p *Provider) AuthScheme() string {
+	return apihelpers.AuthSchemeBearer
 }
 
-// refreshToken fetches a new token from yc CLI with single-flight coordination.
-func (p *Provider) refreshToken(ctx context.Context) (string, error) {
-	// Use singleflight to ensure only one refresh happens at a time
-	result, _, err := p.sf.Do(ctx, tokenRefreshRequestKey, p.doRefresh)
-	if err != nil {
-		return "", err
-	}
-
-	return result, nil
+// Token returns a cached IAM token or fetches a new one if cache is stale.
+func (p *Provider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	return p.cache.token(ctx, forceRefresh)
 }
 
-// doRefresh performs the actual token refresh.
-func (p *Provider) doRefresh(ctx context.Context) (string, error) {
-	token, err := p.executeYC(ctx)
+// fetchToken obtains a new IAM token from yc CLI and caches it until shortly before its reported expiry.
+func (p *Provider) fetchToken(ctx context.Context) (issuedToken, error) {
+	token, expiresAt, err := p.executeYC(ctx)
 	if err != nil {
-		return "", err
+		return issuedToken{}, err
 	}
 
-	// Update cache
-	p.mu.Lock()
-	p.cachedToken = token
-	p.refreshedAt = p.nowFunc()
-	p.mu.Unlock()
-
-	return token, nil
+	return issuedToken{
+		value:     token,
+		expiresAt: cacheExpiry(p.cache.now(), expiresAt, p.refreshPeriod),
+	}, nil
 }
 
-// executeYC runs the yc CLI command and extracts the IAM token using regex.
-func (p *Provider) executeYC(ctx context.Context) (string, error) {
+// executeYC runs the yc CLI command and extracts the IAM token and its expiry.
+// JSON output is preferred; plain output falls back to regex extraction without a known expiry.
+func (p *Provider) executeYC(ctx context.Context) (string, time.Time, error) {
 	output, err := p.executor.Execute(ctx)
 	if err != nil {
 		// Check context errors before sanitizing (sanitizeError breaks error chain)
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-			return "", fmt.Errorf("token fetch canceled or timed out: %w", err)
+			return "", time.Time{}, fmt.Errorf("token fetch canceled or timed out: %w", err)
 		}
 
-		return "", p.logError(ctx, fmt.Errorf("%w: %s", errTokenFetchFailed, p.sanitizeError(err).Error()))
+		return "", time.Time{}, p.logError(ctx, fmt.Errorf("%w: %s", errTokenFetchFailed, p.sanitizeError(err).Error()))
 	}
 
 	if len(output) == 0 {
-		return "", p.logError(ctx, errEmptyToken)
+		return "", time.Time{}, p.logError(ctx, errEmptyToken)
+	}
+
+	if token, expiresAt, ok := p.parseJSONOutput(output); ok {
+		return token, expiresAt, nil
 	}
 
 	match := p.tokenRegex.Find(output)
 	if match == nil {
-		return "", p.logError(ctx, errTokenNotFound)
+		return "", time.Time{}, p.logError(ctx, errTokenNotFound)
+	}
+
+	return string(match), time.Time{}, nil
+}
+
+// parseJSONOutput extracts the token and expiry from `yc iam create-token --format json` output.
+// It reports false when the output is not JSON or the token does not have the expected format.
+func (p *Provider) parseJSONOutput(output []byte) (string, time.Time, bool) {
+	var parsed ycTokenOutput
+	if err := json.Unmarshal(bytes.TrimSpace(output), &parsed); err != nil {
+		return "", time.Time{}, false
+	}
+
+	if parsed.IAMToken == "" || p.tokenRegex.FindString(parsed.IAMToken) != parsed.IAMToken {
+		return "", time.Time{}, false
 	}
 
-	return string(match), nil
+	return parsed.IAMToken, parsed.ExpiresAt, true
 }
@@ -653,3 +653,63 @@ func TestProvider_Token_ForceRefreshFalseUsesCache(t *testing.T) {
 	require.NoError(t, err3)
 	assert.Equal(t, makeValidToken("cached"), tok3)
 }
+
+func TestProvider_Token_ParsesJSONOutputAndHonorsExpiresAt(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockExec := NewMockICommandExecutor(ctrl)
+	provider := NewProvider(testConfig(10 * time.Hour))
+	provider.setExecutor(mockExec)
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newAtomicTime(start)
+	provider.setNowFunc(clock.Now)
+
+	jsonOutput := func(token string) []byte {
+		return []byte(`{"iam_token":"` + token + `","expires_at":"` +
+			clock.Now().Add(time.Hour).Format(time.RFC3339Nano) + `"}`)
+	}
+
+	gomock.InOrder(
+		mockExec.EXPECT().Execute(gomock.Any()).DoAndReturn(func(context.Context) ([]byte, error) {
+			return jsonOutput(makeValidToken("json1")), nil
+		}),
+		mockExec.EXPECT().Execute(gomock.Any()).DoAndReturn(func(context.Context) ([]byte, error) {
+			return jsonOutput(makeValidToken("json2")), nil
+		}),
+	)
+
+	ctx := t.Context()
+
+	tok1, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, makeValidToken("json1"), tok1)
+
+	clock.Advance(50 * time.Minute)
+	tok2, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, makeValidToken("json1"), tok2, "token is valid until expires_at minus safety margin")
+
+	clock.Advance(6 * time.Minute)
+	tok3, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, makeValidToken("json2"), tok3, "token close to expires_at must be refreshed")
+}
+
+func TestProvider_Token_JSONOutputWithInvalidTokenIsRejected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockExec := NewMockICommandExecutor(ctrl)
+	provider := NewProvider(testConfig(time.Hour))
+	provider.setExecutor(mockExec)
+
+	mockExec.EXPECT().
+		Execute(gomock.Any()).
+		Return([]byte(`{"iam_token":"not-a-token","expires_at":"2030-01-01T00:00:00Z"}`), nil)
+
+	_, err := provider.Token(t.Context(), false)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTokenNotFound)
+}
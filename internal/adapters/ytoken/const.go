@@ -1,17 +1,72 @@
 package ytoken
 
+import "time"
+
 // tokenRegexPattern matches Yandex IAM tokens in yc CLI output.
 // Format: t1.[base64-like-chars][optional-padding].[86-base64-like-chars][optional-padding].
 //
 //nolint:gosec // G101: This is a regex pattern, not a hardcoded credential.
 const tokenRegexPattern = `t1\.[A-Z0-9a-z_-]+[=]{0,2}\.[A-Z0-9a-z_-]{86}[=]{0,2}`
 
-// ycCommandName is the only allowed CLI command for IAM token retrieval.
+// ycCommandName is the default CLI command for IAM token retrieval, looked up in PATH.
 const ycCommandName = "yc"
 
 // yc CLI fixed arguments for IAM token retrieval.
 const (
 	ycCommandArgIAM         = "iam"
 	ycCommandArgCreateToken = "create-token"
+	ycCommandArgFormat      = "--format"
+	ycCommandArgFormatJSON  = "json"
+	ycCommandArgProfile     = "--profile"
 	tokenRefreshRequestKey  = "token"
+	chainResolveRequestKey  = "chain"
+)
+
+// maxYCStderrBytes limits the yc stderr included in error messages.
+const maxYCStderrBytes = 1024
+
+// On-disk token cache parameters.
+const (
+	// tokenCacheDirName is the directory under the user cache directory that holds persisted tokens.
+	tokenCacheDirName = "yandex-mcp"
+	// tokenCacheDirPerm restricts the token cache directory to the current user.
+	tokenCacheDirPerm = 0o700
+	// tokenStoreKeyBytes is the number of key hash bytes used in token cache file names.
+	tokenStoreKeyBytes = 16
+)
+
+// Chain resolution parameters.
+const (
+	// metadataProbeTimeout bounds the metadata service probe when it is one of several chain links.
+	metadataProbeTimeout = 2 * time.Second
+	// maxAuthReasonBytes limits failure reasons stored for diagnostics.
+	maxAuthReasonBytes = 512
+)
+
+// Background refresh parameters.
+const (
+	// refreshAheadMax is the maximum lead time before cache expiry at which the background refresher renews a token.
+	refreshAheadMax = 10 * time.Minute
+	// refreshAheadFraction is the share of token cache lifetime used as refresh lead time when it is shorter.
+	refreshAheadFraction = 5
+	// refreshBackoffMin is the initial delay between failed background refresh attempts.
+	refreshBackoffMin = time.Second
+	// refreshBackoffMax caps the delay between failed background refresh attempts.
+	refreshBackoffMax = 5 * time.Minute
+)
+
+// Token endpoint parameters for HTTP-based providers.
+const (
+	// jwtLifetime is the validity period of a signed JWT; IAM accepts at most one hour.
+	jwtLifetime = time.Hour
+	// tokenExpiryMargin is subtracted from upstream token expiry to avoid serving nearly expired tokens.
+	tokenExpiryMargin = 5 * time.Minute
+	// maxTokenResponseBytes limits the token endpoint response size.
+	maxTokenResponseBytes = 1 << 20
+	// maxTokenErrorDetailsBytes limits the token endpoint error body included in error messages.
+	maxTokenErrorDetailsBytes = 512
+	// metadataFlavorHeader is required by the compute metadata service.
+	metadataFlavorHeader = "Metadata-Flavor"
+	// metadataFlavorValue is the only accepted Metadata-Flavor value.
+	metadataFlavorValue = "Google"
 )
@@ -11,6 +11,18 @@ var (
 	errTokenFetchFailed = errors.New("failed to fetch IAM token")
 	errEmptyToken       = errors.New("empty token received from yc")
 	errTokenNotFound    = errors.New("token not found in yc output")
+
+	errOAuthTokenNotConfigured = errors.New("OAuth token is not configured")
+	errOAuthTokenReadFailed    = errors.New("failed to read OAuth token file")
+	errEmptyOAuthToken         = errors.New("empty OAuth token")
+
+	errServiceAccountKeyRead    = errors.New("failed to read service account key file")
+	errServiceAccountKeyInvalid = errors.New("invalid service account key")
+	errEmptyIAMToken            = errors.New("empty IAM token received from IAM endpoint")
+
+	errEmptyMetadataToken = errors.New("empty IAM token received from metadata service")
+
+	errNoAuthSource = errors.New("no usable auth source")
 )
 
 // sanitizeError redacts IAM token patterns from error messages.
@@ -24,5 +36,10 @@ func (p *Provider) sanitizeError(err error) error {
 }
 
 func (p *Provider) logError(ctx context.Context, err error) error {
+	return logError(ctx, err)
+}
+
+// logError logs token acquisition errors with a common prefix.
+func logError(ctx context.Context, err error) error {
 	return domain.LogError(ctx, "token", err)
 }
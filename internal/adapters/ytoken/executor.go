@@ -1,27 +1,77 @@
 package ytoken
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
 )
 
 // commandExecutor implements ICommandExecutor using os/exec.
-type commandExecutor struct{}
+type commandExecutor struct {
+	path    string
+	profile string
+	timeout time.Duration
+}
 
 var _ ICommandExecutor = (*commandExecutor)(nil)
 
 // newCommandExecutor creates a new commandExecutor.
-func newCommandExecutor() *commandExecutor {
-	return &commandExecutor{}
+// An empty path runs yc from PATH; a non-positive timeout leaves the invocation bounded only by the caller context.
+func newCommandExecutor(path, profile string, timeout time.Duration) *commandExecutor {
+	if path == "" {
+		path = ycCommandName
+	}
+	return &commandExecutor{
+		path:    path,
+		profile: profile,
+		timeout: timeout,
+	}
 }
 
 // Execute runs a command and returns its stdout output.
+// On failure the sanitized stderr of the command is included in the error.
 func (e *commandExecutor) Execute(ctx context.Context) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, ycCommandName, ycCommandArgIAM, ycCommandArgCreateToken)
+	runCtx := ctx
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, e.path, e.args()...) //nolint:gosec // G204: path and profile are validated config
+	cmd.Stderr = &stderr
+
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("command execution failed: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("command execution failed: %w", ctxErr)
+		}
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("command execution failed: yc did not finish within %s: %w",
+				e.timeout, context.DeadlineExceeded)
+		}
+
+		details := strings.TrimSpace(domain.SanitizeBody(stderr.String(), maxYCStderrBytes))
+		if details == "" {
+			return nil, fmt.Errorf("command execution failed: %w", err)
+		}
+		return nil, fmt.Errorf("command execution failed: %w: %s", err, details)
 	}
 	return output, nil
 }
+
+// args builds the yc arguments for IAM token creation.
+func (e *commandExecutor) args() []string {
+	args := []string{ycCommandArgIAM, ycCommandArgCreateToken, ycCommandArgFormat, ycCommandArgFormatJSON}
+	if e.profile != "" {
+		args = append(args, ycCommandArgProfile, e.profile)
+	}
+	return args
+}
@@ -1,18 +1,92 @@
 package ytoken
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// writeFakeYC creates an executable shell script standing in for the yc CLI.
+func writeFakeYC(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stand-in is not supported on windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "yc")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700)) //nolint:gosec // test script
+	return path
+}
+
 // TestCommandExecutor_ExecuteWrapsExecutionError verifies command execution failures are wrapped with context.
 func TestCommandExecutor_ExecuteWrapsExecutionError(t *testing.T) {
 	t.Setenv("PATH", "")
-	exec := newCommandExecutor()
+	exec := newCommandExecutor("", "", 0)
 
 	_, err := exec.Execute(t.Context())
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "command execution failed")
 }
+
+func TestCommandExecutor_ExecutePassesArgsAndProfile(t *testing.T) {
+	t.Parallel()
+	path := writeFakeYC(t, `echo "$@"`)
+
+	output, err := newCommandExecutor(path, "prod", time.Minute).Execute(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "iam create-token --format json --profile prod", strings.TrimSpace(string(output)))
+
+	output, err = newCommandExecutor(path, "", time.Minute).Execute(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "iam create-token --format json", strings.TrimSpace(string(output)))
+}
+
+func TestCommandExecutor_ExecuteIncludesSanitizedStderr(t *testing.T) {
+	t.Parallel()
+	path := writeFakeYC(t, `printf 'ERROR: profile "prod" not found\001\n' >&2; exit 1`)
+
+	_, err := newCommandExecutor(path, "prod", time.Minute).Execute(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "command execution failed")
+	assert.Contains(t, err.Error(), `ERROR: profile "prod" not found`)
+	assert.NotContains(t, err.Error(), "\x01")
+}
+
+func TestCommandExecutor_ExecuteLimitsStderr(t *testing.T) {
+	t.Parallel()
+	path := writeFakeYC(t, `head -c 10000 /dev/zero | tr '\0' 'x' >&2; exit 1`)
+
+	_, err := newCommandExecutor(path, "", time.Minute).Execute(t.Context())
+	require.Error(t, err)
+	assert.Less(t, len(err.Error()), maxYCStderrBytes+200)
+}
+
+func TestCommandExecutor_ExecuteTimesOut(t *testing.T) {
+	t.Parallel()
+	path := writeFakeYC(t, `exec sleep 10`)
+
+	start := time.Now()
+	_, err := newCommandExecutor(path, "", 100*time.Millisecond).Execute(t.Context())
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "did not finish within 100ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCommandExecutor_ExecuteReportsParentCancellation(t *testing.T) {
+	t.Parallel()
+	path := writeFakeYC(t, `exec sleep 10`)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := newCommandExecutor(path, "", time.Minute).Execute(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
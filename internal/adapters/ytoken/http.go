@@ -0,0 +1,43 @@
+package ytoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// doTokenRequest executes a token endpoint request and decodes its JSON response into result.
+// Non-2xx responses are reported with a sanitized, size-limited body snippet.
+func doTokenRequest(ctx context.Context, client *http.Client, req *http.Request, result any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("token fetch canceled or timed out: %w", err)
+		}
+		return logError(ctx, fmt.Errorf("%w: %w", errTokenFetchFailed, err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseBytes))
+	if err != nil {
+		return logError(ctx, fmt.Errorf("%w: read response: %w", errTokenFetchFailed, err))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return logError(ctx, fmt.Errorf("%w: HTTP %d: %s", errTokenFetchFailed,
+			resp.StatusCode, domain.SanitizeBody(string(body), maxTokenErrorDetailsBytes)))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return logError(ctx, fmt.Errorf("%w: decode response: %w", errTokenFetchFailed, err))
+	}
+
+	return nil
+}
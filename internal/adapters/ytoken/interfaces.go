@@ -9,3 +9,9 @@ type ICommandExecutor interface {
 	// Execute runs a command and returns its stdout output or an error.
 	Execute(ctx context.Context) ([]byte, error)
 }
+
+// ITokenMetrics records token refresh metrics.
+type ITokenMetrics interface {
+	// ObserveTokenRefresh records a token refresh from the credential source; err is nil on success.
+	ObserveTokenRefresh(org, source string, err error)
+}
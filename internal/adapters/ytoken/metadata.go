@@ -0,0 +1,93 @@
+package ytoken
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// metadataTokenResponse is the compute metadata service token response body.
+type metadataTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// MetadataProvider implements ITokenProvider by fetching IAM tokens of the attached service account
+// from the compute instance metadata service, with caching and single-flight behavior.
+type MetadataProvider struct {
+	metadataURL   string
+	refreshPeriod time.Duration
+	httpClient    *http.Client
+	cache         *tokenCache
+}
+
+// Compile-time interface assertions.
+var _ apihelpers.ITokenProvider = (*MetadataProvider)(nil)
+
+// NewMetadataProvider creates a new metadata service token provider.
+func NewMetadataProvider(cfg *config.Config) *MetadataProvider {
+	p := &MetadataProvider{
+		metadataURL:   cfg.MetadataURL,
+		refreshPeriod: cfg.IAMTokenRefreshPeriod,
+		httpClient:    apihelpers.NewMetadataHTTPClient(cfg),
+		cache:         nil, // set below
+	}
+	p.cache = newTokenCache(p.fetchToken)
+
+	return p
+}
+
+// setNowFunc sets the time function for testing. Not thread-safe; call before use.
+func (p *MetadataProvider) setNowFunc(fn func() time.Time) {
+	p.cache.nowFunc = fn
+}
+
+// StartRefresher starts renewing the token in the background ahead of its expiry until ctx is done.
+// Call it once after construction; Token keeps working without it, refreshing on demand.
+func (p *MetadataProvider) StartRefresher(ctx context.Context) {
+	p.cache.startRefresher(ctx)
+}
+
+// AuthScheme returns the Authorization header scheme for IAM tokens.
+func (p *MetadataProvider) AuthScheme() string {
+	return apihelpers.AuthSchemeBearer
+}
+
+// Token returns a cached IAM token or fetches a new one from the metadata service if cache is stale.
+func (p *MetadataProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	return p.cache.token(ctx, forceRefresh)
+}
+
+// fetchToken requests an IAM token from the metadata service and honors the returned expires_in.
+func (p *MetadataProvider) fetchToken(ctx context.Context) (issuedToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL, nil)
+	if err != nil {
+		return issuedToken{}, logError(ctx, fmt.Errorf("create metadata token request: %w", err))
+	}
+	req.Header.Set(metadataFlavorHeader, metadataFlavorValue)
+
+	now := p.cache.now()
+
+	var resp metadataTokenResponse
+	if err := doTokenRequest(ctx, p.httpClient, req, &resp); err != nil {
+		return issuedToken{}, err
+	}
+
+	if resp.AccessToken == "" {
+		return issuedToken{}, logError(ctx, errEmptyMetadataToken)
+	}
+
+	var upstreamExpiry time.Time
+	if resp.ExpiresIn > 0 {
+		upstreamExpiry = now.Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+
+	return issuedToken{
+		value:     resp.AccessToken,
+		expiresAt: cacheExpiry(now, upstreamExpiry, p.refreshPeriod),
+	}, nil
+}
@@ -0,0 +1,135 @@
+package ytoken
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+func testMetadataConfig(metadataURL string) *config.Config {
+	//nolint:exhaustruct // only metadata fields relevant for these tests
+	return &config.Config{
+		AuthMethod:            config.AuthMethodMetadata,
+		MetadataURL:           metadataURL,
+		IAMTokenRefreshPeriod: 10 * time.Hour,
+		HTTPTimeout:           5 * time.Second,
+	}
+}
+
+func TestMetadataProvider_Token_HonorsExpiresIn(t *testing.T) {
+	t.Parallel()
+
+	clock := newAtomicTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, metadataFlavorValue, r.Header.Get(metadataFlavorHeader))
+
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"t1.vm-%d","expires_in":3600,"token_type":"Bearer"}`, n)
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewMetadataProvider(testMetadataConfig(server.URL))
+	provider.setNowFunc(clock.Now)
+
+	ctx := t.Context()
+
+	tok1, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.vm-1", tok1)
+
+	clock.Advance(50 * time.Minute)
+	tok2, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.vm-1", tok2)
+
+	clock.Advance(6 * time.Minute)
+	tok3, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.vm-2", tok3)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMetadataProvider_Token_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{name: "upstream error", status: http.StatusNotFound, body: "no service account", expected: errTokenFetchFailed},
+		{name: "invalid json", status: http.StatusOK, body: "not-json", expected: errTokenFetchFailed},
+		{name: "empty token", status: http.StatusOK, body: `{"expires_in":3600}`, expected: errEmptyMetadataToken},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			t.Cleanup(server.Close)
+
+			provider := NewMetadataProvider(testMetadataConfig(server.URL))
+
+			_, err := provider.Token(t.Context(), false)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		upstreamExpiry time.Time
+		refreshPeriod  time.Duration
+		expected       time.Time
+	}{
+		{name: "no upstream expiry", upstreamExpiry: time.Time{}, refreshPeriod: time.Hour, expected: now.Add(time.Hour)},
+		{
+			name:           "upstream expiry before refresh period",
+			upstreamExpiry: now.Add(time.Hour),
+			refreshPeriod:  10 * time.Hour,
+			expected:       now.Add(time.Hour - tokenExpiryMargin),
+		},
+		{
+			name:           "refresh period before upstream expiry",
+			upstreamExpiry: now.Add(12 * time.Hour),
+			refreshPeriod:  10 * time.Hour,
+			expected:       now.Add(10 * time.Hour),
+		},
+		{
+			name:           "short lifetime uses half as margin",
+			upstreamExpiry: now.Add(4 * time.Minute),
+			refreshPeriod:  10 * time.Hour,
+			expected:       now.Add(2 * time.Minute),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, cacheExpiry(now, testCase.upstreamExpiry, testCase.refreshPeriod))
+		})
+	}
+}
@@ -54,3 +54,39 @@ func (mr *MockICommandExecutorMockRecorder) Execute(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockICommandExecutor)(nil).Execute), ctx)
 }
+
+// MockITokenMetrics is a mock of ITokenMetrics interface.
+type MockITokenMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenMetricsMockRecorder is the mock recorder for MockITokenMetrics.
+type MockITokenMetricsMockRecorder struct {
+	mock *MockITokenMetrics
+}
+
+// NewMockITokenMetrics creates a new mock instance.
+func NewMockITokenMetrics(ctrl *gomock.Controller) *MockITokenMetrics {
+	mock := &MockITokenMetrics{ctrl: ctrl}
+	mock.recorder = &MockITokenMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenMetrics) EXPECT() *MockITokenMetricsMockRecorder {
+	return m.recorder
+}
+
+// ObserveTokenRefresh mocks base method.
+func (m *MockITokenMetrics) ObserveTokenRefresh(org, source string, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveTokenRefresh", org, source, err)
+}
+
+// ObserveTokenRefresh indicates an expected call of ObserveTokenRefresh.
+func (mr *MockITokenMetricsMockRecorder) ObserveTokenRefresh(org, source, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveTokenRefresh", reflect.TypeOf((*MockITokenMetrics)(nil).ObserveTokenRefresh), org, source, err)
+}
@@ -0,0 +1,99 @@
+package ytoken
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// OAuthProvider implements ITokenProvider backed by a static Yandex OAuth token.
+// The token is taken from configuration directly or read from a file;
+// a file-based token is re-read on forced refresh to pick up rotated credentials.
+type OAuthProvider struct {
+	staticToken string
+	tokenFile   string
+	readFile    func(name string) ([]byte, error)
+
+	mu          sync.RWMutex
+	cachedToken string
+}
+
+// Compile-time interface assertions.
+var _ apihelpers.ITokenProvider = (*OAuthProvider)(nil)
+
+// NewOAuthProvider creates a new OAuth token provider.
+func NewOAuthProvider(cfg *config.Config) *OAuthProvider {
+	return newOAuthProvider(cfg.OAuthToken, cfg.OAuthTokenFile)
+}
+
+// newOAuthProvider creates an OAuth token provider from a static token or a token file.
+func newOAuthProvider(staticToken, tokenFile string) *OAuthProvider {
+	//nolint:exhaustruct // cache and sync fields intentionally start with zero values
+	return &OAuthProvider{
+		staticToken: staticToken,
+		tokenFile:   tokenFile,
+		readFile:    os.ReadFile,
+	}
+}
+
+// setReadFile sets the file reader for testing. Not thread-safe; call before use.
+func (p *OAuthProvider) setReadFile(fn func(name string) ([]byte, error)) {
+	p.readFile = fn
+}
+
+// AuthScheme returns the Authorization header scheme for OAuth tokens.
+func (p *OAuthProvider) AuthScheme() string {
+	return apihelpers.AuthSchemeOAuth
+}
+
+// Token returns the configured OAuth token, re-reading the token file on forced refresh.
+func (p *OAuthProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	if p.staticToken != "" {
+		return p.staticToken, nil
+	}
+
+	if !forceRefresh {
+		p.mu.RLock()
+		token := p.cachedToken
+		p.mu.RUnlock()
+
+		if token != "" {
+			return token, nil
+		}
+	}
+
+	token, err := p.readTokenFile(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.cachedToken = token
+	p.mu.Unlock()
+
+	return token, nil
+}
+
+// readTokenFile loads the OAuth token from the configured file.
+func (p *OAuthProvider) readTokenFile(ctx context.Context) (string, error) {
+	if p.tokenFile == "" {
+		return "", logError(ctx, errOAuthTokenNotConfigured)
+	}
+
+	content, err := p.readFile(p.tokenFile)
+	if err != nil {
+		return "", logError(ctx, fmt.Errorf("%w: %w", errOAuthTokenReadFailed, err))
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", logError(ctx, errEmptyOAuthToken)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,113 @@
+package ytoken
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+func testOAuthConfig(token, tokenFile string) *config.Config {
+	//nolint:exhaustruct // only OAuth fields relevant for OAuth provider tests
+	return &config.Config{
+		AuthMethod:     config.AuthMethodOAuth,
+		OAuthToken:     token,
+		OAuthTokenFile: tokenFile,
+	}
+}
+
+func TestOAuthProvider_AuthScheme(t *testing.T) {
+	t.Parallel()
+
+	provider := NewOAuthProvider(testOAuthConfig("y0_static", ""))
+	assert.Equal(t, apihelpers.AuthSchemeOAuth, provider.AuthScheme())
+}
+
+func TestOAuthProvider_Token_ReturnsStaticToken(t *testing.T) {
+	t.Parallel()
+
+	provider := NewOAuthProvider(testOAuthConfig("y0_static", ""))
+	provider.setReadFile(func(string) ([]byte, error) {
+		t.Fatal("token file must not be read when static token is configured")
+		return nil, nil
+	})
+
+	token, err := provider.Token(t.Context(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "y0_static", token)
+}
+
+func TestOAuthProvider_Token_CachesFileTokenUntilForcedRefresh(t *testing.T) {
+	t.Parallel()
+
+	provider := NewOAuthProvider(testOAuthConfig("", "/secrets/oauth-token"))
+
+	reads := 0
+	provider.setReadFile(func(name string) ([]byte, error) {
+		assert.Equal(t, "/secrets/oauth-token", name)
+		reads++
+		if reads == 1 {
+			return []byte("y0_first\n"), nil
+		}
+		return []byte("  y0_rotated  "), nil
+	})
+
+	ctx := t.Context()
+
+	tok1, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "y0_first", tok1)
+
+	tok2, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "y0_first", tok2)
+	assert.Equal(t, 1, reads)
+
+	tok3, err := provider.Token(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, "y0_rotated", tok3)
+	assert.Equal(t, 2, reads)
+}
+
+func TestOAuthProvider_Token_FileErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		content  []byte
+		readErr  error
+		expected error
+	}{
+		{name: "read failure", content: nil, readErr: fs.ErrNotExist, expected: errOAuthTokenReadFailed},
+		{name: "empty file", content: []byte(" \n"), readErr: nil, expected: errEmptyOAuthToken},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := NewOAuthProvider(testOAuthConfig("", "/secrets/oauth-token"))
+			provider.setReadFile(func(string) ([]byte, error) {
+				return testCase.content, testCase.readErr
+			})
+
+			_, err := provider.Token(t.Context(), false)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+}
+
+func TestOAuthProvider_Token_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	provider := NewOAuthProvider(testOAuthConfig("", ""))
+
+	_, err := provider.Token(t.Context(), false)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errOAuthTokenNotConfigured)
+}
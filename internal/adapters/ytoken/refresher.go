@@ -0,0 +1,65 @@
+package ytoken
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/pause"
+)
+
+// startRefresher launches a background goroutine that renews the cached token ahead of its expiry,
+// so callers are served from cache instead of blocking on token acquisition. It stops when ctx is done.
+func (c *tokenCache) startRefresher(ctx context.Context) {
+	go c.runRefresher(ctx)
+}
+
+// runRefresher renews the token ahead of expiry, retrying failures with jittered exponential backoff.
+func (c *tokenCache) runRefresher(ctx context.Context) {
+	backoff := refreshBackoffMin
+	// minWait prevents a busy loop when upstream issues tokens that are already close to expiry.
+	var minWait time.Duration
+
+	for {
+		if !pause.Sleep(ctx, max(c.nextRefreshIn(), minWait)) {
+			return
+		}
+
+		if _, err := c.refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			delay := pause.Jitter(backoff)
+			slog.WarnContext(ctx, "background token refresh failed",
+				slog.String("error", err.Error()),
+				slog.Duration("retry_in", delay),
+			)
+
+			if !pause.Sleep(ctx, delay) {
+				return
+			}
+
+			backoff = min(backoff*2, refreshBackoffMax)
+			continue
+		}
+
+		backoff = refreshBackoffMin
+		minWait = refreshBackoffMin
+	}
+}
+
+// nextRefreshIn returns how long to wait before renewing the cached token.
+// The lead time is a fraction of the token cache lifetime, capped by refreshAheadMax.
+func (c *tokenCache) nextRefreshIn() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.cached.value == "" {
+		return 0
+	}
+
+	lead := min(c.cached.expiresAt.Sub(c.refreshedAt)/refreshAheadFraction, refreshAheadMax)
+
+	return max(c.cached.expiresAt.Add(-lead).Sub(c.now()), 0)
+}
@@ -0,0 +1,105 @@
+package ytoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCache_RefresherRenewsAheadOfExpiry(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+	cache := newTokenCache(func(context.Context) (issuedToken, error) {
+		n := fetches.Add(1)
+		return issuedToken{
+			value:     fmt.Sprintf("token-%d", n),
+			expiresAt: time.Now().Add(500 * time.Millisecond),
+		}, nil
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	cache.startRefresher(ctx)
+
+	// Initial warm-up fetch happens without any caller.
+	require.Eventually(t, func() bool { return fetches.Load() >= 1 }, time.Second, 10*time.Millisecond)
+
+	token, err := cache.token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The token is renewed before it expires.
+	require.Eventually(t, func() bool { return fetches.Load() >= 2 }, 2*time.Second, 10*time.Millisecond)
+
+	token, ok := cache.get()
+	require.True(t, ok, "renewed token must be served from cache")
+	assert.NotEqual(t, "token-1", token)
+}
+
+func TestTokenCache_RefresherRetriesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+	cache := newTokenCache(func(context.Context) (issuedToken, error) {
+		if fetches.Add(1) == 1 {
+			return issuedToken{}, errors.New("yc unavailable")
+		}
+		return issuedToken{value: "recovered", expiresAt: time.Now().Add(time.Hour)}, nil
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	cache.startRefresher(ctx)
+
+	require.Eventually(t, func() bool {
+		token, ok := cache.get()
+		return ok && token == "recovered"
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestTokenCache_NextRefreshIn(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		lifetime  time.Duration
+		elapsed   time.Duration
+		wantDelay time.Duration
+	}{
+		{name: "long lifetime uses max lead", lifetime: 10 * time.Hour, elapsed: 0, wantDelay: 10*time.Hour - refreshAheadMax},
+		{name: "short lifetime uses fraction", lifetime: 10 * time.Minute, elapsed: 0, wantDelay: 8 * time.Minute},
+		{name: "overdue refresh is immediate", lifetime: time.Hour, elapsed: 2 * time.Hour, wantDelay: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			clock := newAtomicTime(now)
+			cache := newTokenCache(nil)
+			cache.nowFunc = clock.Now
+			cache.cached = issuedToken{value: "token", expiresAt: now.Add(testCase.lifetime)}
+			cache.refreshedAt = now
+
+			clock.Advance(testCase.elapsed)
+			assert.Equal(t, testCase.wantDelay, cache.nextRefreshIn())
+		})
+	}
+
+	t.Run("empty cache refreshes immediately", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, time.Duration(0), newTokenCache(nil).nextRefreshIn())
+	})
+}
@@ -0,0 +1,227 @@
+package ytoken
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// serviceAccountKey is the subset of a Yandex Cloud authorized_key.json used for JWT signing.
+type serviceAccountKey struct {
+	ID               string `json:"id"`
+	ServiceAccountID string `json:"service_account_id"`
+	PrivateKey       string `json:"private_key"`
+}
+
+// iamTokenRequest is the IAM token exchange request body.
+type iamTokenRequest struct {
+	JWT string `json:"jwt"`
+}
+
+// iamTokenResponse is the IAM token exchange response body.
+type iamTokenResponse struct {
+	IAMToken  string    `json:"iamToken"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ServiceAccountProvider implements ITokenProvider by exchanging a service-account signed JWT
+// for an IAM token, with caching and single-flight behavior.
+type ServiceAccountProvider struct {
+	keyID            string
+	serviceAccountID string
+	privateKey       *rsa.PrivateKey
+	endpoint         string
+	refreshPeriod    time.Duration
+	httpClient       *http.Client
+	cache            *tokenCache
+}
+
+// Compile-time interface assertions.
+var _ apihelpers.ITokenProvider = (*ServiceAccountProvider)(nil)
+
+// NewServiceAccountProvider creates a token provider from the configured authorized key file.
+func NewServiceAccountProvider(cfg *config.Config) (*ServiceAccountProvider, error) {
+	keyData, err := os.ReadFile(cfg.ServiceAccountKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errServiceAccountKeyRead, err)
+	}
+
+	key, privateKey, err := parseServiceAccountKey(keyData)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := apihelpers.NewTokenHTTPClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build IAM HTTP client: %w", err)
+	}
+
+	p := &ServiceAccountProvider{
+		keyID:            key.ID,
+		serviceAccountID: key.ServiceAccountID,
+		privateKey:       privateKey,
+		endpoint:         cfg.IAMEndpoint,
+		refreshPeriod:    cfg.IAMTokenRefreshPeriod,
+		httpClient:       httpClient,
+		cache:            nil, // set below
+	}
+	p.cache = newTokenCache(p.fetchToken)
+
+	return p, nil
+}
+
+// setNowFunc sets the time function for testing. Not thread-safe; call before use.
+func (p *ServiceAccountProvider) setNowFunc(fn func() time.Time) {
+	p.cache.nowFunc = fn
+}
+
+// StartRefresher starts renewing the token in the background ahead of its expiry until ctx is done.
+// Call it once after construction; Token keeps working without it, refreshing on demand.
+func (p *ServiceAccountProvider) StartRefresher(ctx context.Context) {
+	p.cache.startRefresher(ctx)
+}
+
+// AuthScheme returns the Authorization header scheme for IAM tokens.
+func (p *ServiceAccountProvider) AuthScheme() string {
+	return apihelpers.AuthSchemeBearer
+}
+
+// Token returns a cached IAM token or exchanges a new JWT if cache is stale.
+func (p *ServiceAccountProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	return p.cache.token(ctx, forceRefresh)
+}
+
+// fetchToken signs a JWT and exchanges it for an IAM token.
+// The token is cached until shortly before its upstream expiry, but no longer than the refresh period.
+func (p *ServiceAccountProvider) fetchToken(ctx context.Context) (issuedToken, error) {
+	now := p.cache.now()
+
+	jwt, err := p.signJWT(now)
+	if err != nil {
+		return issuedToken{}, logError(ctx, err)
+	}
+
+	resp, err := p.exchangeJWT(ctx, jwt)
+	if err != nil {
+		return issuedToken{}, err
+	}
+
+	return issuedToken{
+		value:     resp.IAMToken,
+		expiresAt: cacheExpiry(now, resp.ExpiresAt, p.refreshPeriod),
+	}, nil
+}
+
+// exchangeJWT posts the signed JWT to the IAM endpoint and decodes the issued token.
+func (p *ServiceAccountProvider) exchangeJWT(ctx context.Context, jwt string) (*iamTokenResponse, error) {
+	body, err := json.Marshal(iamTokenRequest{JWT: jwt})
+	if err != nil {
+		return nil, logError(ctx, fmt.Errorf("marshal IAM token request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, logError(ctx, fmt.Errorf("create IAM token request: %w", err))
+	}
+	req.Header.Set(apihelpers.HeaderContentType, apihelpers.ContentTypeJSON)
+
+	var tokenResp iamTokenResponse
+	if err := doTokenRequest(ctx, p.httpClient, req, &tokenResp); err != nil {
+		return nil, err
+	}
+
+	if tokenResp.IAMToken == "" {
+		return nil, logError(ctx, errEmptyIAMToken)
+	}
+
+	return &tokenResp, nil
+}
+
+// signJWT builds a PS256-signed JWT for the IAM token exchange.
+func (p *ServiceAccountProvider) signJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "PS256",
+		"kid": p.keyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT header: %w", err)
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iss": p.serviceAccountID,
+		"aud": p.endpoint,
+		"iat": now.Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPSS(rand.Reader, p.privateKey, crypto.SHA256, digest[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256})
+	if err != nil {
+		return "", fmt.Errorf("sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseServiceAccountKey decodes an authorized key file and its RSA private key.
+func parseServiceAccountKey(data []byte) (*serviceAccountKey, *rsa.PrivateKey, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errServiceAccountKeyInvalid, err)
+	}
+
+	if key.ID == "" || key.ServiceAccountID == "" || key.PrivateKey == "" {
+		return nil, nil, fmt.Errorf("%w: id, service_account_id and private_key are required",
+			errServiceAccountKeyInvalid)
+	}
+
+	// Yandex Cloud prepends a human-readable warning line before the PEM block.
+	pemData := key.PrivateKey
+	if idx := strings.Index(pemData, "-----BEGIN"); idx > 0 {
+		pemData = pemData[idx:]
+	}
+
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, nil, fmt.Errorf("%w: private_key is not PEM encoded", errServiceAccountKeyInvalid)
+	}
+
+	if pkcs1Key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return &key, pkcs1Key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: parse private key: %w", errServiceAccountKeyInvalid, err)
+	}
+
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: private key must be RSA", errServiceAccountKeyInvalid)
+	}
+
+	return &key, rsaKey, nil
+}
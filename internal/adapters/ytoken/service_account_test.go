@@ -0,0 +1,223 @@
+package ytoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+const (
+	testSAKeyID = "aje-key-id"
+	testSAID    = "aje-service-account-id"
+)
+
+// writeTestServiceAccountKey writes an authorized_key.json with a fresh RSA key and returns its path.
+func writeTestServiceAccountKey(t *testing.T) (string, *rsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	pemBlock := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}) //nolint:exhaustruct // headers unused
+	keyJSON, err := json.Marshal(map[string]string{
+		"id":                 testSAKeyID,
+		"service_account_id": testSAID,
+		"key_algorithm":      "RSA_2048",
+		"private_key":        "PLEASE DO NOT REMOVE THIS LINE! Yandex.Cloud SA Key ID <" + testSAKeyID + ">\n" + string(pemBlock),
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "authorized_key.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0o600))
+
+	return path, privateKey
+}
+
+func testServiceAccountConfig(keyFile, endpoint string) *config.Config {
+	//nolint:exhaustruct // only service account fields relevant for these tests
+	return &config.Config{
+		AuthMethod:            config.AuthMethodServiceAccount,
+		ServiceAccountKeyFile: keyFile,
+		IAMEndpoint:           endpoint,
+		IAMTokenRefreshPeriod: 10 * time.Hour,
+		HTTPTimeout:           5 * time.Second,
+	}
+}
+
+// verifyTestJWT checks the JWT signature and returns its header and claims.
+func verifyTestJWT(t *testing.T, jwt string, publicKey *rsa.PublicKey) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPSS(publicKey, crypto.SHA256, digest[:], signature,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}))
+
+	decode := func(segment string) map[string]any {
+		raw, decodeErr := base64.RawURLEncoding.DecodeString(segment)
+		require.NoError(t, decodeErr)
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(raw, &out))
+		return out
+	}
+
+	return decode(parts[0]), decode(parts[1])
+}
+
+func TestServiceAccountProvider_Token_ExchangesSignedJWT(t *testing.T) {
+	t.Parallel()
+
+	keyFile, privateKey := writeTestServiceAccountKey(t)
+
+	var endpoint string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req iamTokenRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		header, claims := verifyTestJWT(t, req.JWT, &privateKey.PublicKey)
+		assert.Equal(t, "PS256", header["alg"])
+		assert.Equal(t, testSAKeyID, header["kid"])
+		assert.Equal(t, testSAID, claims["iss"])
+		assert.Equal(t, endpoint, claims["aud"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"iamToken":"t1.sa-token","expiresAt":"2099-01-01T00:00:00Z"}`))
+	}))
+	t.Cleanup(server.Close)
+	endpoint = server.URL + "/iam/v1/tokens"
+
+	provider, err := NewServiceAccountProvider(testServiceAccountConfig(keyFile, endpoint))
+	require.NoError(t, err)
+	assert.Equal(t, apihelpers.AuthSchemeBearer, provider.AuthScheme())
+
+	token, err := provider.Token(t.Context(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.sa-token", token)
+}
+
+func TestServiceAccountProvider_Token_HonorsUpstreamExpiry(t *testing.T) {
+	t.Parallel()
+
+	keyFile, _ := writeTestServiceAccountKey(t)
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newAtomicTime(start)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		expiresAt := clock.Now().Add(time.Hour).Format(time.RFC3339)
+		_, _ = fmt.Fprintf(w, `{"iamToken":"t1.sa-%d","expiresAt":%q}`, n, expiresAt)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewServiceAccountProvider(testServiceAccountConfig(keyFile, server.URL))
+	require.NoError(t, err)
+	provider.setNowFunc(clock.Now)
+
+	ctx := t.Context()
+
+	tok1, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.sa-1", tok1)
+
+	clock.Advance(30 * time.Minute)
+	tok2, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.sa-1", tok2)
+
+	// Upstream expiry minus safety margin has passed: token must be exchanged again.
+	clock.Advance(26 * time.Minute)
+	tok3, err := provider.Token(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "t1.sa-2", tok3)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestServiceAccountProvider_Token_UpstreamError(t *testing.T) {
+	t.Parallel()
+
+	keyFile, _ := writeTestServiceAccountKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":16,"message":"Invalid JWT"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewServiceAccountProvider(testServiceAccountConfig(keyFile, server.URL))
+	require.NoError(t, err)
+
+	_, err = provider.Token(t.Context(), false)
+	require.Error(t, err)
+	require.ErrorIs(t, err, errTokenFetchFailed)
+	assert.Contains(t, err.Error(), "HTTP 401")
+}
+
+func TestNewServiceAccountProvider_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected error
+	}{
+		{name: "not json", content: "not-json", expected: errServiceAccountKeyInvalid},
+		{name: "missing fields", content: `{"id":"key"}`, expected: errServiceAccountKeyInvalid},
+		{
+			name:     "private key not PEM",
+			content:  `{"id":"key","service_account_id":"sa","private_key":"garbage"}`,
+			expected: errServiceAccountKeyInvalid,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "authorized_key.json")
+			require.NoError(t, os.WriteFile(path, []byte(testCase.content), 0o600))
+
+			_, err := NewServiceAccountProvider(testServiceAccountConfig(path, "https://iam.example.test"))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "missing.json")
+		_, err := NewServiceAccountProvider(testServiceAccountConfig(path, "https://iam.example.test"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errServiceAccountKeyRead)
+	})
+}
@@ -0,0 +1,135 @@
+package ytoken
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// persistedToken is the on-disk format of a cached token.
+type persistedToken struct {
+	Token       string    `json:"token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+// tokenStore persists a cached token in a user-only file so it survives server restarts.
+type tokenStore struct {
+	path string
+}
+
+// newTokenStore creates a store for the token identified by key inside dir.
+// The key is hashed so that file names do not reveal profile names or paths.
+func newTokenStore(dir, kind, key string) *tokenStore {
+	sum := sha256.Sum256([]byte(key))
+	name := kind + "-" + hex.EncodeToString(sum[:tokenStoreKeyBytes]) + ".json"
+	return &tokenStore{path: filepath.Join(dir, name)}
+}
+
+// newYCTokenStore returns the store for yc tokens, or nil when the on-disk cache is disabled or unavailable.
+func newYCTokenStore(cfg *config.Config) (*tokenStore, error) {
+	if !cfg.TokenCacheEnabled {
+		return nil, nil //nolint:nilnil // nil store means persistence is disabled
+	}
+
+	dir, err := resolveTokenCacheDir(cfg.TokenCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenStore(dir, ycCommandName, cfg.YCPath+"\x00"+cfg.YCProfile), nil
+}
+
+// resolveTokenCacheDir returns the configured cache directory or the default one under the user cache directory.
+func resolveTokenCacheDir(configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve user cache dir: %w", err)
+	}
+
+	return filepath.Join(base, tokenCacheDirName), nil
+}
+
+// load reads the persisted token. A missing file or a file readable by other users yields ok=false.
+func (s *tokenStore) load() (persistedToken, bool, error) {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return persistedToken{}, false, nil
+	}
+	if err != nil {
+		return persistedToken{}, false, fmt.Errorf("stat token cache: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return persistedToken{}, false, fmt.Errorf("token cache %s is accessible by other users, ignoring it", s.path)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return persistedToken{}, false, fmt.Errorf("read token cache: %w", err)
+	}
+
+	var token persistedToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return persistedToken{}, false, fmt.Errorf("decode token cache: %w", err)
+	}
+	if token.Token == "" {
+		return persistedToken{}, false, nil
+	}
+
+	return token, true, nil
+}
+
+// save atomically replaces the persisted token with a user-only (0600) file.
+func (s *tokenStore) save(token persistedToken) error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, tokenCacheDirPerm); err != nil {
+		return fmt.Errorf("create token cache dir: %w", err)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encode token cache: %w", err)
+	}
+
+	// CreateTemp creates the file with 0600 permissions.
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create token cache: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("write token cache: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace token cache: %w", err)
+	}
+
+	return nil
+}
+
+// remove deletes the persisted token; a missing file is not an error.
+func (s *tokenStore) remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove token cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package ytoken
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+func persistentTestConfig(dir string) *config.Config {
+	//nolint:exhaustruct // only token cache and yc identity settings are relevant
+	return &config.Config{
+		IAMTokenRefreshPeriod: 10 * time.Hour,
+		YCPath:                "yc",
+		YCProfile:             "work",
+		TokenCacheEnabled:     true,
+		TokenCacheDir:         dir,
+	}
+}
+
+func TestTokenStore_SaveLoadRemove(t *testing.T) {
+	t.Parallel()
+
+	store := newTokenStore(filepath.Join(t.TempDir(), "nested"), "yc", "key")
+	want := persistedToken{
+		Token:       makeValidToken("stored"),
+		ExpiresAt:   time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC),
+		RefreshedAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	_, ok, err := store.load()
+	require.NoError(t, err)
+	assert.False(t, ok, "missing file is not an error")
+
+	require.NoError(t, store.save(want))
+
+	if runtime.GOOS != "windows" {
+		info, statErr := os.Stat(store.path)
+		require.NoError(t, statErr)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	got, ok, err := store.load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, want.Token, got.Token)
+	assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt))
+	assert.True(t, want.RefreshedAt.Equal(got.RefreshedAt))
+
+	require.NoError(t, store.remove())
+	require.NoError(t, store.remove(), "removing a missing file is not an error")
+	_, ok, err = store.load()
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestTokenStore_FileNameDoesNotRevealKey(t *testing.T) {
+	t.Parallel()
+
+	store := newTokenStore(t.TempDir(), "yc", "/opt/yc\x00secret-profile")
+	assert.NotContains(t, filepath.Base(store.path), "secret-profile")
+	assert.NotEqual(t, store.path, newTokenStore(filepath.Dir(store.path), "yc", "other").path)
+}
+
+func TestTokenStore_IgnoresFileAccessibleByOthers(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions are not enforced on windows")
+	}
+
+	store := newTokenStore(t.TempDir(), "yc", "key")
+	require.NoError(t, store.save(persistedToken{Token: "token", ExpiresAt: time.Now().Add(time.Hour)}))
+	require.NoError(t, os.Chmod(store.path, 0o644))
+
+	_, ok, err := store.load()
+	require.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestProvider_PersistentCache_ReusedAcrossInstances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	cfg := persistentTestConfig(t.TempDir())
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newAtomicTime(start)
+
+	first := NewProvider(cfg)
+	firstExec := NewMockICommandExecutor(ctrl)
+	first.setExecutor(firstExec)
+	first.setNowFunc(clock.Now)
+
+	firstExec.EXPECT().Execute(gomock.Any()).Return([]byte(`{"iam_token":"`+makeValidToken("disk")+
+		`","expires_at":"`+start.Add(12*time.Hour).Format(time.RFC3339)+`"}`), nil)
+
+	tok, err := first.Token(t.Context(), false)
+	require.NoError(t, err)
+	assert.Equal(t, makeValidToken("disk"), tok)
+
+	// A restarted server must not run yc while the persisted token is valid.
+	clock.Advance(time.Hour)
+	second := NewProvider(cfg)
+	second.setExecutor(NewMockICommandExecutor(ctrl))
+	second.setNowFunc(clock.Now)
+
+	tok, err = second.Token(t.Context(), false)
+	require.NoError(t, err)
+	assert.Equal(t, makeValidToken("disk"), tok)
+	assert.Equal(t, 9*time.Hour-refreshAheadMax, second.cache.nextRefreshIn(),
+		"restored token keeps its original refresh schedule")
+}
+
+func TestProvider_PersistentCache_ForcedRefreshInvalidates(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	cfg := persistentTestConfig(t.TempDir())
+
+	provider := NewProvider(cfg)
+	mockExec := NewMockICommandExecutor(ctrl)
+	provider.setExecutor(mockExec)
+
+	gomock.InOrder(
+		mockExec.EXPECT().Execute(gomock.Any()).Return([]byte(makeValidToken("first")), nil),
+		mockExec.EXPECT().Execute(gomock.Any()).Return(nil, assert.AnError),
+	)
+
+	_, err := provider.Token(t.Context(), false)
+	require.NoError(t, err)
+	_, ok, err := provider.cache.store.load()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = provider.Token(t.Context(), true)
+	require.Error(t, err)
+
+	_, ok, err = provider.cache.store.load()
+	require.NoError(t, err)
+	assert.False(t, ok, "rejected token must not survive a restart")
+
+	restarted := NewProvider(cfg)
+	_, ok = restarted.cache.get()
+	assert.False(t, ok)
+}
+
+func TestProvider_PersistentCache_ExpiredTokenNotRestored(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := persistentTestConfig(dir)
+
+	store, err := newYCTokenStore(cfg)
+	require.NoError(t, err)
+	require.NoError(t, store.save(persistedToken{
+		Token:       makeValidToken("old"),
+		ExpiresAt:   time.Now().Add(-time.Minute),
+		RefreshedAt: time.Now().Add(-time.Hour),
+	}))
+
+	provider := NewProvider(cfg)
+	_, ok := provider.cache.get()
+	assert.False(t, ok)
+}
+
+func TestProvider_PersistentCache_Disabled(t *testing.T) {
+	t.Parallel()
+	cfg := persistentTestConfig(t.TempDir())
+	cfg.TokenCacheEnabled = false
+
+	provider := NewProvider(cfg)
+	assert.Nil(t, provider.cache.store)
+}
@@ -0,0 +1,238 @@
+// Package audit records tool calls for compliance review, separately from the operational log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+const (
+	// maxArgumentLen bounds the length of a single string argument in the audit log.
+	maxArgumentLen = 256
+	// redacted replaces values of arguments that look like credentials.
+	redacted = "[REDACTED]"
+)
+
+// Error classes of failed tool calls.
+const (
+	ErrorClassInvalidRequest     = "invalid_request"
+	ErrorClassCanceled           = "canceled"
+	ErrorClassTimeout            = "timeout"
+	ErrorClassServiceUnavailable = "service_unavailable"
+	ErrorClassUpstream           = "upstream"
+	ErrorClassTool               = "tool"
+)
+
+// StatusCached marks operations served from the response cache without an upstream request.
+const StatusCached = "cached"
+
+// Record describes a single tool call.
+type Record struct {
+	Time      time.Time      `json:"time"`
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	// Targets holds the identifiers of issues, pages and other objects addressed by the arguments.
+	Targets     map[string]string `json:"targets,omitempty"`
+	Operations  []Operation       `json:"operations,omitempty"`
+	ResultBytes int               `json:"result_bytes"`
+	ErrorClass  string            `json:"error_class,omitempty"`
+	DurationMS  int64             `json:"duration_ms"`
+
+	mu sync.Mutex
+}
+
+// Operation is an upstream API request performed during a tool call.
+type Operation struct {
+	Service   string `json:"service"`
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+	// Path is the endpoint path without the query string.
+	Path string `json:"path"`
+	// Status is the HTTP status code, "error" when no response was received, or "cached".
+	Status string `json:"status"`
+}
+
+// targetArguments lists the tool arguments identifying the objects a call reads.
+func targetArguments() []string {
+	return []string{
+		"issue_id_or_key", "attachment_id", "queue_id_or_key", "project_id", "user_id",
+		"slug", "page_id", "grid_id",
+	}
+}
+
+// NewRecord starts a record of a tool call with the given raw JSON arguments.
+// Arguments that cannot be decoded are omitted.
+func NewRecord(now time.Time, tool string, rawArguments json.RawMessage) *Record {
+	var arguments map[string]any
+	if len(rawArguments) > 0 {
+		if err := json.Unmarshal(rawArguments, &arguments); err != nil {
+			arguments = nil
+		}
+	}
+
+	targets := make(map[string]string)
+	for _, name := range targetArguments() {
+		if value, ok := arguments[name].(string); ok && value != "" {
+			targets[name] = domain.SanitizeBody(value, maxArgumentLen)
+		}
+	}
+
+	return &Record{
+		Time:        now,
+		Tool:        domain.SanitizeBody(tool, maxArgumentLen),
+		Arguments:   sanitizeArguments(arguments),
+		Targets:     targets,
+		Operations:  nil,
+		ResultBytes: 0,
+		ErrorClass:  "",
+		DurationMS:  0,
+		mu:          sync.Mutex{},
+	}
+}
+
+// AddOperation appends an upstream operation. It is a no-op on a nil record.
+func (r *Record) AddOperation(op Operation) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Operations = append(r.Operations, op)
+}
+
+// SetOutcome records the size of the tool output and the class of its error.
+// It is a no-op on a nil record.
+func (r *Record) SetOutcome(ctx context.Context, output any, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.ErrorClass = r.classifyError(ctx, err)
+		return
+	}
+
+	if data, marshalErr := json.Marshal(output); marshalErr == nil {
+		r.ResultBytes = len(data)
+	}
+}
+
+// Finish sets the call duration and, for calls rejected before reaching the tool, the error class.
+func (r *Record) Finish(duration time.Duration, rejected bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.DurationMS = duration.Milliseconds()
+	if rejected && r.ErrorClass == "" {
+		r.ErrorClass = ErrorClassInvalidRequest
+	}
+}
+
+// MarshalLine encodes the record as a single JSON line.
+func (r *Record) MarshalLine() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// classifyError maps a tool error to a coarse class. Tool errors are already sanitized and
+// do not carry upstream error types, so the status of the last upstream operation is consulted.
+func (r *Record) classifyError(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case ctx.Err() != nil:
+		return ErrorClassCanceled
+	}
+
+	var unavailableErr domain.ServiceUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return ErrorClassServiceUnavailable
+	}
+
+	if len(r.Operations) > 0 && !isSuccessStatus(r.Operations[len(r.Operations)-1].Status) {
+		return ErrorClassUpstream
+	}
+
+	return ErrorClassTool
+}
+
+// isSuccessStatus reports whether an operation status denotes a usable response.
+func isSuccessStatus(status string) bool {
+	return status == StatusCached || strings.HasPrefix(status, "2") || status == "304"
+}
+
+// sanitizeArguments returns a copy of the arguments with credentials redacted and long strings truncated.
+func sanitizeArguments(arguments map[string]any) map[string]any {
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	sanitized := make(map[string]any, len(arguments))
+	for name, value := range arguments {
+		if isSecretArgument(name) {
+			sanitized[name] = redacted
+			continue
+		}
+		sanitized[name] = sanitizeValue(value)
+	}
+	return sanitized
+}
+
+// sanitizeValue truncates strings and sanitizes nested objects and arrays.
+func sanitizeValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return domain.SanitizeBody(v, maxArgumentLen)
+	case map[string]any:
+		return sanitizeArguments(v)
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, sanitizeValue(item))
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// isSecretArgument reports whether the argument name suggests a credential.
+func isSecretArgument(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range []string{"token", "password", "secret", "credential"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordKey is the context key of the record of the current tool call.
+type recordKey struct{}
+
+// NewContext returns a context carrying the record of the current tool call.
+func NewContext(ctx context.Context, record *Record) context.Context {
+	return context.WithValue(ctx, recordKey{}, record)
+}
+
+// FromContext returns the record of the current tool call, or nil when the call is not audited.
+func FromContext(ctx context.Context) *Record {
+	record, _ := ctx.Value(recordKey{}).(*Record)
+	return record
+}
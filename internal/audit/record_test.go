@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+func TestNewRecord_SanitizesArguments(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	raw := json.RawMessage(`{
+		"issue_id_or_key": "TEST-1",
+		"query": "` + strings.Repeat("a", 300) + `",
+		"filter": {"queue": "TEST", "api_token": "secret"},
+		"access_token": "secret",
+		"per_page": 10
+	}`)
+
+	record := NewRecord(now, "tracker_issue_get", raw)
+
+	assert.Equal(t, now, record.Time)
+	assert.Equal(t, "tracker_issue_get", record.Tool)
+	assert.Equal(t, map[string]string{"issue_id_or_key": "TEST-1"}, record.Targets)
+	assert.Equal(t, "TEST-1", record.Arguments["issue_id_or_key"])
+	assert.Len(t, record.Arguments["query"], maxArgumentLen)
+	assert.Equal(t, map[string]any{"queue": "TEST", "api_token": redacted}, record.Arguments["filter"])
+	assert.Equal(t, redacted, record.Arguments["access_token"])
+	assert.InDelta(t, 10, record.Arguments["per_page"], 0)
+}
+
+func TestNewRecord_InvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	record := NewRecord(time.Now(), "wiki_page_get", json.RawMessage(`not json`))
+
+	assert.Nil(t, record.Arguments)
+	assert.Empty(t, record.Targets)
+}
+
+func TestRecord_SetOutcome(t *testing.T) {
+	t.Parallel()
+
+	canceledCtx, cancel := context.WithCancel(t.Context())
+	cancel()
+	expiredCtx, expire := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	t.Cleanup(expire)
+
+	errTool := errors.New("tool failed")
+
+	testCases := []struct {
+		name           string
+		ctx            context.Context //nolint:containedctx // each case runs with its own context state
+		lastStatus     string
+		err            error
+		wantErrorClass string
+		wantBytes      int
+	}{
+		{name: "success", ctx: t.Context(), lastStatus: "200", err: nil, wantErrorClass: "", wantBytes: 10},
+		{
+			name:           "canceled",
+			ctx:            canceledCtx,
+			lastStatus:     "",
+			err:            errTool,
+			wantErrorClass: ErrorClassCanceled,
+			wantBytes:      0,
+		},
+		{
+			name:           "timeout",
+			ctx:            expiredCtx,
+			lastStatus:     "",
+			err:            errTool,
+			wantErrorClass: ErrorClassTimeout,
+			wantBytes:      0,
+		},
+		{
+			name:           "service unavailable",
+			ctx:            t.Context(),
+			lastStatus:     "",
+			err:            domain.ServiceUnavailableError{Service: domain.ServiceTracker, RetryAfter: time.Second},
+			wantErrorClass: ErrorClassServiceUnavailable,
+			wantBytes:      0,
+		},
+		{
+			name:           "upstream error",
+			ctx:            t.Context(),
+			lastStatus:     "404",
+			err:            errTool,
+			wantErrorClass: ErrorClassUpstream,
+			wantBytes:      0,
+		},
+		{
+			name:           "network error",
+			ctx:            t.Context(),
+			lastStatus:     "error",
+			err:            errTool,
+			wantErrorClass: ErrorClassUpstream,
+			wantBytes:      0,
+		},
+		{
+			name:           "tool error",
+			ctx:            t.Context(),
+			lastStatus:     "200",
+			err:            errTool,
+			wantErrorClass: ErrorClassTool,
+			wantBytes:      0,
+		},
+		{
+			name:           "tool error after cache hit",
+			ctx:            t.Context(),
+			lastStatus:     StatusCached,
+			err:            errTool,
+			wantErrorClass: ErrorClassTool,
+			wantBytes:      0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			record := NewRecord(time.Now(), "tool", nil)
+			if testCase.lastStatus != "" {
+				record.AddOperation(Operation{
+					Service:   "tracker",
+					Operation: "GetIssue",
+					Method:    "GET",
+					Path:      "/v3/issues/TEST-1",
+					Status:    testCase.lastStatus,
+				})
+			}
+
+			record.SetOutcome(testCase.ctx, map[string]string{"id": "1"}, testCase.err)
+
+			assert.Equal(t, testCase.wantErrorClass, record.ErrorClass)
+			assert.Equal(t, testCase.wantBytes, record.ResultBytes)
+		})
+	}
+}
+
+func TestRecord_Finish(t *testing.T) {
+	t.Parallel()
+
+	record := NewRecord(time.Now(), "missing_tool", nil)
+	record.Finish(1500*time.Millisecond, true)
+
+	line, err := record.MarshalLine()
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(line), "}\n"))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(line, &decoded))
+	assert.Equal(t, "missing_tool", decoded["tool"])
+	assert.Equal(t, ErrorClassInvalidRequest, decoded["error_class"])
+	assert.InDelta(t, 1500, decoded["duration_ms"], 0)
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, FromContext(t.Context()))
+
+	// operations on a missing record are ignored
+	FromContext(t.Context()).AddOperation(Operation{
+		Service:   "wiki",
+		Operation: "GetPage",
+		Method:    "GET",
+		Path:      "/v1/pages",
+		Status:    "200",
+	})
+	FromContext(t.Context()).SetOutcome(t.Context(), nil, nil)
+
+	record := NewRecord(time.Now(), "tool", nil)
+	assert.Same(t, record, FromContext(NewContext(t.Context(), record)))
+}
@@ -0,0 +1,157 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+const (
+	// logFilePerm restricts the audit log to the server user.
+	logFilePerm = 0o600
+	// logDirPerm is used when the audit log directory has to be created.
+	logDirPerm = 0o700
+)
+
+// FileSink appends records to a JSONL file, rotating it by size.
+// Rotated files are named <path>.1 (the newest) to <path>.<maxBackups>; older ones are removed.
+type FileSink struct {
+	path       string
+	maxBytes   int64 // zero disables rotation
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileSink opens the audit log at path for appending, creating it and its directory if needed.
+func NewFileSink(path string, maxBytes int64, maxBackups int) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), logDirPerm); err != nil {
+		return nil, fmt.Errorf("create audit log directory: %w", err)
+	}
+
+	sink := &FileSink{
+		path:       path,
+		maxBytes:   maxBytes,
+		maxBackups: maxBackups,
+		mu:         sync.Mutex{},
+		file:       nil,
+		size:       0,
+	}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// Write appends the record as a single line, rotating the file first when the line would exceed the size limit.
+// A failed rotation is reported, but the record is still written while the log file is open.
+func (s *FileSink) Write(record *Record) error {
+	line, err := record.MarshalLine()
+	if err != nil {
+		return fmt.Errorf("encode audit record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("audit log is closed")
+	}
+
+	var rotateErr error
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		rotateErr = s.rotate()
+		if s.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return errors.Join(rotateErr, fmt.Errorf("write audit record: %w", err))
+	}
+
+	return rotateErr
+}
+
+// Close closes the audit log file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// open opens the current log file for appending and picks up its size.
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, logFilePerm)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat audit log: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file to <path>.1 and starts a new file.
+// The log is reopened even if shifting the backups fails, so auditing continues.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("close audit log: %w", err)
+	}
+	s.file = nil
+
+	shiftErr := s.shiftBackups()
+	if err := s.open(); err != nil {
+		return errors.Join(shiftErr, err)
+	}
+	return shiftErr
+}
+
+// shiftBackups renames <path>.n to <path>.n+1 and the current file to <path>.1, dropping the oldest backup.
+func (s *FileSink) shiftBackups() error {
+	if s.maxBackups == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove audit log: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Remove(s.backupPath(s.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove oldest audit log: %w", err)
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate audit log: %w", err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("rotate audit log: %w", err)
+	}
+
+	return nil
+}
+
+// backupPath returns the name of the n-th rotated file.
+func (s *FileSink) backupPath(n int) string {
+	return s.path + "." + strconv.Itoa(n)
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readTools returns the tool names of the records stored in a JSONL file.
+func readTools(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	var tools []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		tools = append(tools, record.Tool)
+	}
+	require.NoError(t, scanner.Err())
+	return tools
+}
+
+// recordLineSize returns the size of an encoded record of the tool.
+func recordLineSize(t *testing.T, tool string) int64 {
+	t.Helper()
+	line, err := NewRecord(time.Time{}, tool, nil).MarshalLine()
+	require.NoError(t, err)
+	return int64(len(line))
+}
+
+func TestFileSink_WritesJSONLines(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(NewRecord(time.Time{}, "tool-1", nil)))
+	require.NoError(t, sink.Close())
+
+	// reopening appends to the existing file
+	sink, err = NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(NewRecord(time.Time{}, "tool-2", nil)))
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, []string{"tool-1", "tool-2"}, readTools(t, path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(logFilePerm), info.Mode().Perm())
+
+	require.Error(t, sink.Write(NewRecord(time.Time{}, "tool-3", nil)), "writes after close must fail")
+}
+
+func TestFileSink_RotatesBySize(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	// each file holds two records
+	sink, err := NewFileSink(path, 2*recordLineSize(t, "tool-1"), 2)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sink.Close() })
+
+	for _, tool := range []string{"tool-1", "tool-2", "tool-3", "tool-4", "tool-5", "tool-6", "tool-7"} {
+		require.NoError(t, sink.Write(NewRecord(time.Time{}, tool, nil)))
+	}
+
+	assert.Equal(t, []string{"tool-7"}, readTools(t, path))
+	assert.Equal(t, []string{"tool-5", "tool-6"}, readTools(t, path+".1"))
+	assert.Equal(t, []string{"tool-3", "tool-4"}, readTools(t, path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestFileSink_RotatesWithoutBackups(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	sink, err := NewFileSink(path, recordLineSize(t, "tool-1"), 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sink.Close() })
+
+	require.NoError(t, sink.Write(NewRecord(time.Time{}, "tool-1", nil)))
+	require.NoError(t, sink.Write(NewRecord(time.Time{}, "tool-2", nil)))
+
+	assert.Equal(t, []string{"tool-2"}, readTools(t, path))
+	assert.NoFileExists(t, path+".1")
+}
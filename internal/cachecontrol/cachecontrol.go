@@ -0,0 +1,19 @@
+// Package cachecontrol lets callers ask the API adapters to skip their response caches,
+// e.g. for health probes that must observe the current state of the upstream services.
+package cachecontrol
+
+import "context"
+
+// bypassKey is the context key of the cache bypass flag.
+type bypassKey struct{}
+
+// NewBypassContext returns a context whose API requests skip response caches and always reach the upstream service.
+func NewBypassContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// Bypassed reports whether API requests of the context must skip response caches.
+func Bypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassKey{}).(bool)
+	return bypassed
+}
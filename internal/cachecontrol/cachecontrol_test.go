@@ -0,0 +1,14 @@
+package cachecontrol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBypassed(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Bypassed(t.Context()))
+	assert.True(t, Bypassed(NewBypassContext(t.Context())))
+}
@@ -0,0 +1,70 @@
+// Package callerauth carries the Yandex credentials supplied by the MCP client of the current tool call,
+// so upstream requests run with the caller's permissions instead of the server's.
+package callerauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Header is the HTTP header in which MCP clients pass their Yandex credentials,
+// e.g. "OAuth <token>" or "Bearer <IAM token>".
+const Header = "X-Yandex-Authorization"
+
+// Supported authorization schemes.
+const (
+	SchemeOAuth  = "OAuth"
+	SchemeBearer = "Bearer"
+)
+
+// idBytes is the number of token hash bytes identifying the credentials.
+const idBytes = 8
+
+// Credentials is a Yandex OAuth or IAM token of the caller.
+type Credentials struct {
+	Scheme string
+	Token  string
+}
+
+// ParseHeader parses the value of the credentials header.
+func ParseHeader(value string) (Credentials, error) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+	token = strings.TrimSpace(token)
+	if !found || token == "" {
+		return Credentials{}, errors.New(Header + ": expected \"OAuth <token>\" or \"Bearer <token>\"")
+	}
+
+	switch {
+	case strings.EqualFold(scheme, SchemeOAuth):
+		scheme = SchemeOAuth
+	case strings.EqualFold(scheme, SchemeBearer):
+		scheme = SchemeBearer
+	default:
+		return Credentials{}, errors.New(Header + ": unsupported scheme, expected OAuth or Bearer")
+	}
+
+	return Credentials{Scheme: scheme, Token: token}, nil
+}
+
+// ID returns a stable identifier of the credentials that does not reveal the token.
+func (c Credentials) ID() string {
+	sum := sha256.Sum256([]byte(c.Scheme + " " + c.Token))
+	return hex.EncodeToString(sum[:idBytes])
+}
+
+// credentialsKey is the context key of the caller credentials.
+type credentialsKey struct{}
+
+// NewContext returns a context carrying the caller credentials.
+func NewContext(ctx context.Context, credentials Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, credentials)
+}
+
+// FromContext returns the caller credentials, or false when the call uses the server's credentials.
+func FromContext(ctx context.Context) (Credentials, bool) {
+	credentials, ok := ctx.Value(credentialsKey{}).(Credentials)
+	return credentials, ok
+}
@@ -0,0 +1,65 @@
+package callerauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeader(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		value   string
+		want    Credentials
+		wantErr bool
+	}{
+		{name: "oauth", value: "OAuth y0_token", want: Credentials{Scheme: SchemeOAuth, Token: "y0_token"}, wantErr: false},
+		{name: "bearer", value: " bearer  t1.iam ", want: Credentials{Scheme: SchemeBearer, Token: "t1.iam"}, wantErr: false},
+		{name: "empty", value: "", want: Credentials{}, wantErr: true},
+		{name: "missing token", value: "OAuth ", want: Credentials{}, wantErr: true},
+		{name: "token only", value: "y0_token", want: Credentials{}, wantErr: true},
+		{name: "unsupported scheme", value: "Basic dXNlcg==", want: Credentials{}, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseHeader(testCase.value)
+			if testCase.wantErr {
+				require.Error(t, err)
+				assert.NotContains(t, err.Error(), "dXNlcg", "the token must not be echoed")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestCredentials_ID(t *testing.T) {
+	t.Parallel()
+
+	first := Credentials{Scheme: SchemeOAuth, Token: "token-1"}
+	second := Credentials{Scheme: SchemeOAuth, Token: "token-2"}
+
+	assert.Len(t, first.ID(), 2*idBytes)
+	assert.Equal(t, first.ID(), first.ID())
+	assert.NotEqual(t, first.ID(), second.ID())
+	assert.NotContains(t, first.ID(), "token")
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, ok := FromContext(t.Context())
+	assert.False(t, ok)
+
+	credentials := Credentials{Scheme: SchemeBearer, Token: "t1.iam"}
+	got, ok := FromContext(NewContext(t.Context(), credentials))
+	assert.True(t, ok)
+	assert.Equal(t, credentials, got)
+}
@@ -1,11 +1,14 @@
-// Package config loads application configuration from environment variables.
+// Package config loads application configuration from an optional file, environment variables and flags.
 package config
 
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,27 +18,275 @@ import (
 const (
 	defaultTrackerBaseURL       = "https://api.tracker.yandex.net"
 	defaultWikiBaseURL          = "https://api.wiki.yandex.net"
+	defaultIAMEndpoint          = "https://iam.api.cloud.yandex.net/iam/v1/tokens"
+	defaultMetadataURL          = "http://169.254.169.254/computeMetadata/v1/instance/service-accounts/default/token"
+	defaultYCCommand            = "yc"
+	defaultOrgName              = "default"
 	defaultRefreshHours         = 10
 	defaultAttachInlineMaxBytes = 10 * 1024 * 1024
+	bytesPerMegabyte            = 1024 * 1024
 )
 
-// Config holds static application configuration loaded from environment variables.
+// OrgType selects the kind of organization the Tracker and Wiki instances are bound to.
+type OrgType string
+
+// Supported organization types.
+const (
+	// OrgTypeCloud is a Yandex Cloud organization, identified by the X-Cloud-Org-Id header.
+	OrgTypeCloud OrgType = "cloud"
+	// OrgType360 is a Yandex 360 organization, identified by the X-Org-ID header.
+	OrgType360 OrgType = "360"
+)
+
+// FixturesMode selects whether Tracker and Wiki HTTP exchanges are recorded to or replayed from fixtures.
+type FixturesMode string
+
+// Supported fixtures modes.
+const (
+	// FixturesModeOff sends requests to the network without recording them.
+	FixturesModeOff FixturesMode = ""
+	// FixturesModeRecord sends requests to the network and saves every exchange to the fixtures directory.
+	FixturesModeRecord FixturesMode = "record"
+	// FixturesModeReplay serves responses from the fixtures directory without network access.
+	FixturesModeReplay FixturesMode = "replay"
+)
+
+// MCPTransport selects how MCP clients connect to the server.
+type MCPTransport string
+
+// Supported MCP transports.
+const (
+	// MCPTransportStdio serves a single client over stdin/stdout.
+	MCPTransportStdio MCPTransport = "stdio"
+	// MCPTransportHTTP serves many clients over streamable HTTP.
+	MCPTransportHTTP MCPTransport = "http"
+)
+
+// CallerCredentialsMode selects whether HTTP-mode clients may call Tracker and Wiki with their own Yandex token.
+type CallerCredentialsMode string
+
+// Supported caller credentials modes.
+const (
+	// CallerCredentialsOff ignores caller tokens; all clients share the server's credentials.
+	CallerCredentialsOff CallerCredentialsMode = "off"
+	// CallerCredentialsOptional uses the caller's token when supplied and the server's credentials otherwise.
+	CallerCredentialsOptional CallerCredentialsMode = "optional"
+	// CallerCredentialsRequired rejects clients that do not supply their own token.
+	CallerCredentialsRequired CallerCredentialsMode = "required"
+)
+
+// AuthMethod selects how API credentials are obtained.
+type AuthMethod string
+
+// Supported authentication methods.
+const (
+	// AuthMethodYC obtains IAM tokens via the yc CLI.
+	AuthMethodYC AuthMethod = "yc"
+	// AuthMethodOAuth uses a Yandex OAuth token from the environment or a file.
+	AuthMethodOAuth AuthMethod = "oauth"
+	// AuthMethodServiceAccount exchanges a service-account authorized key for IAM tokens.
+	AuthMethodServiceAccount AuthMethod = "service_account"
+	// AuthMethodMetadata fetches IAM tokens of the attached service account from the compute metadata service.
+	AuthMethodMetadata AuthMethod = "metadata"
+	// AuthMethodChain tries the credential sources from AuthChain in order and uses the first that works.
+	AuthMethodChain AuthMethod = "chain"
+)
+
+// AuthSource identifies a single credential source.
+type AuthSource string
+
+// Supported credential sources.
+const (
+	AuthSourceOAuthToken     AuthSource = "oauth_token"
+	AuthSourceOAuthTokenFile AuthSource = "oauth_token_file"
+	AuthSourceServiceAccount AuthSource = "service_account"
+	AuthSourceMetadata       AuthSource = "metadata"
+	AuthSourceYC             AuthSource = "yc"
+)
+
+// defaultAuthChain is the credential source order used by the chain method when none is configured.
+func defaultAuthChain() []AuthSource {
+	return []AuthSource{
+		AuthSourceOAuthToken,
+		AuthSourceOAuthTokenFile,
+		AuthSourceServiceAccount,
+		AuthSourceMetadata,
+		AuthSourceYC,
+	}
+}
+
+// Config holds static application configuration merged from an optional configuration file,
+// environment variables and command line flags, in increasing order of precedence.
 type Config struct {
+	// ConfigFile is the configuration file the settings were read from; empty when there is none.
+	ConfigFile string
+
 	// WikiBaseURL is the base URL for Yandex Wiki API.
 	WikiBaseURL string
 
 	// TrackerBaseURL is the base URL for Yandex Tracker API.
 	TrackerBaseURL string
 
-	// CloudOrgID is the Yandex Cloud Organization ID for X-Cloud-Org-Id header.
+	// CloudOrgID is the organization ID sent in the header selected by OrgType.
 	CloudOrgID string
 
+	// OrgType is the organization type that selects the organization ID header.
+	OrgType OrgType
+
+	// OrgName is the name of this organization profile, selected by the tools' org argument.
+	OrgName string
+
+	// OrgProfiles are additional named organization profiles; set only on the primary configuration.
+	OrgProfiles []*Config
+
+	// InheritsAuth reports that the profile overrides no authentication settings of the primary configuration,
+	// so it can share the primary token provider.
+	InheritsAuth bool
+
+	// AuthMethod selects the token provider used for API authentication.
+	AuthMethod AuthMethod
+
+	// AuthChain is the ordered list of credential sources to try.
+	// For single-source methods it contains exactly that source.
+	AuthChain []AuthSource
+
+	// OAuthToken is the Yandex OAuth token used when AuthMethod is oauth.
+	OAuthToken string
+
+	// OAuthTokenFile is the path to a file containing the Yandex OAuth token used when AuthMethod is oauth.
+	OAuthTokenFile string
+
+	// ServiceAccountKeyFile is the path to a service-account authorized_key.json
+	// used when AuthMethod is service_account.
+	ServiceAccountKeyFile string
+
+	// IAMEndpoint is the IAM token exchange endpoint used when AuthMethod is service_account.
+	IAMEndpoint string
+
+	// MetadataURL is the compute metadata service token URL used when AuthMethod is metadata.
+	MetadataURL string
+
+	// YCPath is the yc CLI executable: "yc" (looked up in PATH) or an absolute path.
+	YCPath string
+
+	// YCProfile is the yc CLI profile passed via --profile; empty means the active profile.
+	YCProfile string
+
+	// YCTimeout bounds a single yc CLI invocation.
+	YCTimeout time.Duration
+
+	// TokenCacheEnabled enables persisting yc IAM tokens on disk so they survive server restarts.
+	TokenCacheEnabled bool
+
+	// TokenCacheDir is the directory for persisted tokens; empty means a directory under the user cache directory.
+	TokenCacheDir string
+
 	// IAMTokenRefreshPeriod is the period after which the IAM token should be refreshed.
 	IAMTokenRefreshPeriod time.Duration
 
 	// HTTPTimeout is the timeout for HTTP requests to Yandex APIs.
 	HTTPTimeout time.Duration
 
+	// TrackerRateLimit is the sustained Tracker request rate per second; zero disables client-side rate limiting.
+	TrackerRateLimit float64
+
+	// TrackerRateBurst is the number of Tracker requests that may be sent at once before the rate limit applies.
+	TrackerRateBurst int
+
+	// WikiRateLimit is the sustained Wiki request rate per second; zero disables client-side rate limiting.
+	WikiRateLimit float64
+
+	// WikiRateBurst is the number of Wiki requests that may be sent at once before the rate limit applies.
+	WikiRateBurst int
+
+	// CircuitBreakerThreshold is the number of consecutive failed requests that stops calls to a service;
+	// zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerOpenTimeout is how long calls to a failing service are rejected before a probe request.
+	CircuitBreakerOpenTimeout time.Duration
+
+	// HTTPRetryMaxAttempts is the total number of attempts for idempotent requests; 1 disables retries.
+	HTTPRetryMaxAttempts int
+
+	// HTTPRetryBaseDelay is the backoff before the first retry; it doubles with every further retry.
+	HTTPRetryBaseDelay time.Duration
+
+	// HTTPRetryMaxDelay caps the retry backoff and the accepted Retry-After value.
+	HTTPRetryMaxDelay time.Duration
+
+	// HTTPProxyURL is the proxy for Tracker and Wiki requests; empty uses the HTTPS_PROXY/NO_PROXY environment.
+	HTTPProxyURL string
+
+	// HTTPCAFile is a PEM bundle of additional root certificates trusted for Tracker and Wiki TLS connections.
+	HTTPCAFile string
+
+	// HTTPClientCertFile and HTTPClientKeyFile are the PEM client certificate and key for mutual TLS.
+	HTTPClientCertFile string
+	HTTPClientKeyFile  string
+
+	// HTTPMaxIdleConns limits idle keep-alive connections across all hosts.
+	HTTPMaxIdleConns int
+
+	// HTTPMaxIdleConnsPerHost limits idle keep-alive connections per host.
+	HTTPMaxIdleConnsPerHost int
+
+	// HTTPMaxConnsPerHost limits all connections per host; zero means no limit.
+	HTTPMaxConnsPerHost int
+
+	// HTTPIdleConnTimeout is how long an idle keep-alive connection is kept open.
+	HTTPIdleConnTimeout time.Duration
+
+	// HTTPMaxResponseBytes limits the size of a JSON response body read from Tracker and Wiki.
+	HTTPMaxResponseBytes int64
+
+	// HTTPFixturesMode records Tracker and Wiki HTTP exchanges to HTTPFixturesDir or replays them from it.
+	HTTPFixturesMode FixturesMode
+
+	// HTTPFixturesDir is the directory of recorded HTTP exchanges.
+	HTTPFixturesDir string
+
+	// HTTPCacheEnabled enables the in-memory cache of GET responses for rarely changing resources.
+	HTTPCacheEnabled bool
+
+	// HTTPCacheMaxEntries is the maximum number of cached responses per service.
+	HTTPCacheMaxEntries int
+
+	// TracingEnabled enables exporting OpenTelemetry traces over OTLP/HTTP.
+	// The exporter is configured by the standard OTEL_EXPORTER_OTLP_* variables.
+	TracingEnabled bool
+
+	// AuditLogPath is the JSONL file recording every tool call; empty disables the audit log.
+	AuditLogPath string
+
+	// AuditLogMaxBytes is the size at which the audit log is rotated; zero disables rotation.
+	AuditLogMaxBytes int64
+
+	// AuditLogMaxBackups is the number of rotated audit log files to keep.
+	AuditLogMaxBackups int
+
+	// Transport is how MCP clients connect to the server.
+	Transport MCPTransport
+
+	// Listen is the address of the streamable HTTP listener.
+	Listen string
+
+	// TLSCertFile and TLSKeyFile are the PEM certificate and private key of the streamable HTTP listener.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// MetricsListen is the address serving Prometheus metrics; empty disables the metrics endpoint.
+	MetricsListen string
+
+	// ClientTokensFile lists the bearer tokens accepted from MCP clients in the HTTP transport mode.
+	ClientTokensFile string
+
+	// CallerCredentials controls whether HTTP-mode clients may supply their own Yandex token.
+	CallerCredentials CallerCredentialsMode
+
+	// Tools selects the Tracker and Wiki tools registered with the MCP server.
+	Tools ToolSelection
+
 	// AttachAllowedExtensions is the list of allowed attachment extensions (without dots).
 	AttachAllowedExtensions []string
 
@@ -51,22 +302,83 @@ type Config struct {
 
 // envConfig is an intermediate struct for parsing environment variables.
 type envConfig struct {
-	WikiBaseURL          string `env:"YANDEX_WIKI_BASE_URL"`
-	TrackerBaseURL       string `env:"YANDEX_TRACKER_BASE_URL"`
-	CloudOrgID           string `env:"YANDEX_CLOUD_ORG_ID,required"`
-	RefreshPeriodHours   int    `env:"YANDEX_IAM_TOKEN_REFRESH_PERIOD" envDefault:"10"`
-	HTTPTimeoutSeconds   int    `env:"YANDEX_HTTP_TIMEOUT" envDefault:"30"`
-	AttachExtensions     string `env:"YANDEX_MCP_ATTACH_EXT"`
-	AttachViewExts       string `env:"YANDEX_MCP_ATTACH_VIEW_EXT"`
-	AttachDirs           string `env:"YANDEX_MCP_ATTACH_DIR"`
-	AttachInlineMaxBytes int64  `env:"YANDEX_MCP_ATTACH_INLINE_MAX_BYTES" envDefault:"10485760"`
-}
-
-// Load parses configuration from environment variables and validates it.
-// It should be called once at application startup.
+	WikiBaseURL               string  `env:"YANDEX_WIKI_BASE_URL"`
+	TrackerBaseURL            string  `env:"YANDEX_TRACKER_BASE_URL"`
+	CloudOrgID                string  `env:"YANDEX_CLOUD_ORG_ID,required"`
+	OrgType                   string  `env:"YANDEX_ORG_TYPE" envDefault:"cloud"`
+	OrgName                   string  `env:"YANDEX_ORG_NAME" envDefault:"default"`
+	AuthMethod                string  `env:"YANDEX_AUTH_METHOD" envDefault:"yc"`
+	AuthChain                 string  `env:"YANDEX_AUTH_CHAIN"`
+	OAuthToken                string  `env:"YANDEX_OAUTH_TOKEN"`
+	OAuthTokenFile            string  `env:"YANDEX_OAUTH_TOKEN_FILE"`
+	SAKeyFile                 string  `env:"YANDEX_SA_KEY_FILE"`
+	IAMEndpoint               string  `env:"YANDEX_IAM_ENDPOINT"`
+	MetadataURL               string  `env:"YANDEX_METADATA_URL"`
+	YCPath                    string  `env:"YANDEX_YC_PATH"`
+	YCProfile                 string  `env:"YANDEX_YC_PROFILE"`
+	YCTimeoutSeconds          int     `env:"YANDEX_YC_TIMEOUT" envDefault:"120"`
+	TokenCache                bool    `env:"YANDEX_TOKEN_CACHE" envDefault:"true"`
+	TokenCacheDir             string  `env:"YANDEX_TOKEN_CACHE_DIR"`
+	RefreshPeriodHours        int     `env:"YANDEX_IAM_TOKEN_REFRESH_PERIOD" envDefault:"10"`
+	HTTPTimeoutSeconds        int     `env:"YANDEX_HTTP_TIMEOUT" envDefault:"30"`
+	TrackerRateLimit          float64 `env:"YANDEX_TRACKER_RATE_LIMIT" envDefault:"10"`
+	TrackerRateBurst          int     `env:"YANDEX_TRACKER_RATE_BURST" envDefault:"10"`
+	WikiRateLimit             float64 `env:"YANDEX_WIKI_RATE_LIMIT" envDefault:"10"`
+	WikiRateBurst             int     `env:"YANDEX_WIKI_RATE_BURST" envDefault:"10"`
+	CircuitBreakerThreshold   int     `env:"YANDEX_CIRCUIT_BREAKER_THRESHOLD" envDefault:"5"`
+	CircuitBreakerOpenTimeout int     `env:"YANDEX_CIRCUIT_BREAKER_OPEN_TIMEOUT" envDefault:"30"`
+	HTTPRetryMaxAttempts      int     `env:"YANDEX_HTTP_RETRY_MAX_ATTEMPTS" envDefault:"3"`
+	HTTPRetryBaseDelayMS      int     `env:"YANDEX_HTTP_RETRY_BASE_DELAY_MS" envDefault:"500"`
+	HTTPRetryMaxDelayMS       int     `env:"YANDEX_HTTP_RETRY_MAX_DELAY_MS" envDefault:"10000"`
+	HTTPProxy                 string  `env:"YANDEX_HTTP_PROXY"`
+	HTTPCAFile                string  `env:"YANDEX_HTTP_CA_FILE"`
+	HTTPClientCertFile        string  `env:"YANDEX_HTTP_CLIENT_CERT_FILE"`
+	HTTPClientKeyFile         string  `env:"YANDEX_HTTP_CLIENT_KEY_FILE"`
+	HTTPMaxIdleConns          int     `env:"YANDEX_HTTP_MAX_IDLE_CONNS" envDefault:"100"`
+	HTTPMaxIdleConnsPerHost   int     `env:"YANDEX_HTTP_MAX_IDLE_CONNS_PER_HOST" envDefault:"10"`
+	HTTPMaxConnsPerHost       int     `env:"YANDEX_HTTP_MAX_CONNS_PER_HOST" envDefault:"0"`
+	HTTPIdleConnTimeout       int     `env:"YANDEX_HTTP_IDLE_CONN_TIMEOUT" envDefault:"90"`
+	HTTPMaxResponseSizeMB     int64   `env:"YANDEX_HTTP_MAX_RESPONSE_SIZE_MB" envDefault:"64"`
+	HTTPFixturesMode          string  `env:"YANDEX_HTTP_FIXTURES_MODE"`
+	HTTPFixturesDir           string  `env:"YANDEX_HTTP_FIXTURES_DIR"`
+	HTTPCache                 bool    `env:"YANDEX_HTTP_CACHE" envDefault:"true"`
+	HTTPCacheMaxEntries       int     `env:"YANDEX_HTTP_CACHE_MAX_ENTRIES" envDefault:"500"`
+	TracingEnabled            bool    `env:"YANDEX_OTEL_TRACING" envDefault:"false"`
+	AuditLog                  string  `env:"YANDEX_AUDIT_LOG"`
+	AuditLogMaxSizeMB         int64   `env:"YANDEX_AUDIT_LOG_MAX_SIZE_MB" envDefault:"100"`
+	AuditLogMaxBackups        int     `env:"YANDEX_AUDIT_LOG_MAX_BACKUPS" envDefault:"5"`
+	Transport                 string  `env:"YANDEX_MCP_TRANSPORT" envDefault:"stdio"`
+	Listen                    string  `env:"YANDEX_MCP_LISTEN"`
+	TLSCertFile               string  `env:"YANDEX_MCP_TLS_CERT"`
+	TLSKeyFile                string  `env:"YANDEX_MCP_TLS_KEY"`
+	MetricsListen             string  `env:"YANDEX_MCP_METRICS_LISTEN"`
+	ClientTokensFile          string  `env:"YANDEX_MCP_CLIENT_TOKENS_FILE"`
+	CallerCredentials         string  `env:"YANDEX_MCP_CALLER_CREDENTIALS" envDefault:"off"`
+	Services                  string  `env:"YANDEX_MCP_SERVICES" envDefault:"tracker,wiki"`
+	ToolsAllow                string  `env:"YANDEX_MCP_TOOLS_ALLOW"`
+	ToolsDeny                 string  `env:"YANDEX_MCP_TOOLS_DENY"`
+	AttachExtensions          string  `env:"YANDEX_MCP_ATTACH_EXT"`
+	AttachViewExts            string  `env:"YANDEX_MCP_ATTACH_VIEW_EXT"`
+	AttachDirs                string  `env:"YANDEX_MCP_ATTACH_DIR"`
+	AttachInlineMaxBytes      int64   `env:"YANDEX_MCP_ATTACH_INLINE_MAX_BYTES" envDefault:"10485760"`
+}
+
+// Load parses configuration from environment variables only and validates it.
+// It should be called once at application startup; use LoadWithOptions to read a configuration file and flags too.
 func Load() (*Config, error) {
+	effective := &Effective{
+		File:     "",
+		Settings: nil,
+		environ:  env.ToMap(os.Environ()),
+	}
+	return effective.Load()
+}
+
+// loadEnviron parses and validates a single configuration from the given environment.
+func loadEnviron(environ map[string]string) (*Config, error) {
 	var ec envConfig
-	if err := env.Parse(&ec); err != nil {
+	//nolint:exhaustruct // only the environment source is overridden
+	if err := env.ParseWithOptions(&ec, env.Options{Environment: environ}); err != nil {
 		return nil, fmt.Errorf("parse env config: %w", err)
 	}
 
@@ -91,16 +403,79 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	tools, err := parseToolSelection(ec)
+	if err != nil {
+		return nil, err
+	}
+
+	authMethod := AuthMethod(strings.ToLower(strings.TrimSpace(ec.AuthMethod)))
+	oauthToken := strings.TrimSpace(ec.OAuthToken)
+
+	authChain, err := resolveAuthChain(authMethod, ec.AuthChain, oauthToken)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
-		WikiBaseURL:             applyDefault(ec.WikiBaseURL, defaultWikiBaseURL),
-		TrackerBaseURL:          applyDefault(ec.TrackerBaseURL, defaultTrackerBaseURL),
-		CloudOrgID:              ec.CloudOrgID,
-		IAMTokenRefreshPeriod:   resolveRefreshPeriod(ec.RefreshPeriodHours),
-		HTTPTimeout:             time.Duration(ec.HTTPTimeoutSeconds) * time.Second,
-		AttachAllowedExtensions: allowedExtensions,
-		AttachViewExtensions:    viewExtensions,
-		AttachAllowedDirs:       allowedDirs,
-		AttachInlineMaxBytes:    ec.AttachInlineMaxBytes,
+		WikiBaseURL:               applyDefault(ec.WikiBaseURL, defaultWikiBaseURL),
+		TrackerBaseURL:            applyDefault(ec.TrackerBaseURL, defaultTrackerBaseURL),
+		CloudOrgID:                ec.CloudOrgID,
+		OrgType:                   OrgType(strings.ToLower(strings.TrimSpace(ec.OrgType))),
+		OrgName:                   applyDefault(strings.ToLower(strings.TrimSpace(ec.OrgName)), defaultOrgName),
+		OrgProfiles:               nil,
+		InheritsAuth:              false,
+		AuthMethod:                authMethod,
+		AuthChain:                 authChain,
+		OAuthToken:                oauthToken,
+		OAuthTokenFile:            strings.TrimSpace(ec.OAuthTokenFile),
+		ServiceAccountKeyFile:     strings.TrimSpace(ec.SAKeyFile),
+		IAMEndpoint:               applyDefault(ec.IAMEndpoint, defaultIAMEndpoint),
+		MetadataURL:               applyDefault(ec.MetadataURL, defaultMetadataURL),
+		YCPath:                    applyDefault(strings.TrimSpace(ec.YCPath), defaultYCCommand),
+		YCProfile:                 strings.TrimSpace(ec.YCProfile),
+		YCTimeout:                 time.Duration(ec.YCTimeoutSeconds) * time.Second,
+		TokenCacheEnabled:         ec.TokenCache,
+		TokenCacheDir:             strings.TrimSpace(ec.TokenCacheDir),
+		IAMTokenRefreshPeriod:     resolveRefreshPeriod(ec.RefreshPeriodHours),
+		HTTPTimeout:               time.Duration(ec.HTTPTimeoutSeconds) * time.Second,
+		TrackerRateLimit:          ec.TrackerRateLimit,
+		TrackerRateBurst:          ec.TrackerRateBurst,
+		WikiRateLimit:             ec.WikiRateLimit,
+		WikiRateBurst:             ec.WikiRateBurst,
+		CircuitBreakerThreshold:   ec.CircuitBreakerThreshold,
+		CircuitBreakerOpenTimeout: time.Duration(ec.CircuitBreakerOpenTimeout) * time.Second,
+		HTTPRetryMaxAttempts:      ec.HTTPRetryMaxAttempts,
+		HTTPRetryBaseDelay:        time.Duration(ec.HTTPRetryBaseDelayMS) * time.Millisecond,
+		HTTPRetryMaxDelay:         time.Duration(ec.HTTPRetryMaxDelayMS) * time.Millisecond,
+		HTTPProxyURL:              strings.TrimSpace(ec.HTTPProxy),
+		HTTPCAFile:                strings.TrimSpace(ec.HTTPCAFile),
+		HTTPClientCertFile:        strings.TrimSpace(ec.HTTPClientCertFile),
+		HTTPClientKeyFile:         strings.TrimSpace(ec.HTTPClientKeyFile),
+		HTTPMaxIdleConns:          ec.HTTPMaxIdleConns,
+		HTTPMaxIdleConnsPerHost:   ec.HTTPMaxIdleConnsPerHost,
+		HTTPMaxConnsPerHost:       ec.HTTPMaxConnsPerHost,
+		HTTPIdleConnTimeout:       time.Duration(ec.HTTPIdleConnTimeout) * time.Second,
+		HTTPMaxResponseBytes:      ec.HTTPMaxResponseSizeMB * bytesPerMegabyte,
+		HTTPFixturesMode:          FixturesMode(strings.ToLower(strings.TrimSpace(ec.HTTPFixturesMode))),
+		HTTPFixturesDir:           strings.TrimSpace(ec.HTTPFixturesDir),
+		HTTPCacheEnabled:          ec.HTTPCache,
+		HTTPCacheMaxEntries:       ec.HTTPCacheMaxEntries,
+		TracingEnabled:            ec.TracingEnabled,
+		AuditLogPath:              strings.TrimSpace(ec.AuditLog),
+		AuditLogMaxBytes:          ec.AuditLogMaxSizeMB * bytesPerMegabyte,
+		AuditLogMaxBackups:        ec.AuditLogMaxBackups,
+		Transport:                 MCPTransport(strings.ToLower(strings.TrimSpace(ec.Transport))),
+		Listen:                    strings.TrimSpace(ec.Listen),
+		TLSCertFile:               strings.TrimSpace(ec.TLSCertFile),
+		TLSKeyFile:                strings.TrimSpace(ec.TLSKeyFile),
+		MetricsListen:             strings.TrimSpace(ec.MetricsListen),
+		ClientTokensFile:          strings.TrimSpace(ec.ClientTokensFile),
+		CallerCredentials:         CallerCredentialsMode(strings.ToLower(strings.TrimSpace(ec.CallerCredentials))),
+		Tools:                     tools,
+		AttachAllowedExtensions:   allowedExtensions,
+		AttachViewExtensions:      viewExtensions,
+		AttachAllowedDirs:         allowedDirs,
+		AttachInlineMaxBytes:      ec.AttachInlineMaxBytes,
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -269,6 +644,66 @@ func (c *Config) validate() error {
 	if c.CloudOrgID == "" {
 		errs = append(errs, errors.New("YANDEX_CLOUD_ORG_ID is required"))
 	}
+	if c.TokenCacheDir != "" && !filepath.IsAbs(c.TokenCacheDir) {
+		errs = append(errs, fmt.Errorf("YANDEX_TOKEN_CACHE_DIR: must be absolute path, got %q", c.TokenCacheDir))
+	}
+	if !isValidOrgName(c.OrgName) {
+		errs = append(errs, fmt.Errorf("YANDEX_ORG_NAME: invalid profile name %q", c.OrgName))
+	}
+	switch c.OrgType {
+	case OrgTypeCloud, OrgType360:
+	default:
+		errs = append(errs, fmt.Errorf("YANDEX_ORG_TYPE: unsupported value %q (expected %q or %q)",
+			c.OrgType, OrgTypeCloud, OrgType360))
+	}
+	if err := c.validateAuth(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateRateLimit(c.TrackerRateLimit, c.TrackerRateBurst, "TRACKER"); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateRateLimit(c.WikiRateLimit, c.WikiRateBurst, "WIKI"); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.validateRetry(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.CircuitBreakerThreshold < 0 {
+		errs = append(errs, errors.New("YANDEX_CIRCUIT_BREAKER_THRESHOLD must not be negative"))
+	}
+	if c.CircuitBreakerThreshold > 0 && c.CircuitBreakerOpenTimeout <= 0 {
+		errs = append(errs, errors.New("YANDEX_CIRCUIT_BREAKER_OPEN_TIMEOUT must be positive"))
+	}
+	if err := c.validateTransport(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.HTTPMaxResponseBytes <= 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_MAX_RESPONSE_SIZE_MB must be positive"))
+	}
+	if err := c.validateFixtures(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.HTTPCacheEnabled && c.HTTPCacheMaxEntries <= 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_CACHE_MAX_ENTRIES must be positive"))
+	}
+	if err := c.validateAuditLog(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.validateMCPTransport(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.ClientTokensFile != "" && !filepath.IsAbs(c.ClientTokensFile) {
+		errs = append(errs, fmt.Errorf("YANDEX_MCP_CLIENT_TOKENS_FILE: must be absolute path, got %q", c.ClientTokensFile))
+	}
+	if err := c.Tools.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	switch c.CallerCredentials {
+	case CallerCredentialsOff, CallerCredentialsOptional, CallerCredentialsRequired:
+	default:
+		errs = append(errs, fmt.Errorf("YANDEX_MCP_CALLER_CREDENTIALS: unsupported value %q (expected %q, %q or %q)",
+			c.CallerCredentials, CallerCredentialsOff, CallerCredentialsOptional, CallerCredentialsRequired))
+	}
 	if len(c.AttachAllowedExtensions) == 0 {
 		errs = append(errs, errors.New("YANDEX_MCP_ATTACH_EXT resolved to an empty list"))
 	}
@@ -282,6 +717,277 @@ func (c *Config) validate() error {
 	return errors.Join(errs...)
 }
 
+// resolveAuthChain returns the credential sources to try for the given authentication method.
+func resolveAuthChain(method AuthMethod, rawChain, oauthToken string) ([]AuthSource, error) {
+	switch method {
+	case AuthMethodYC:
+		return []AuthSource{AuthSourceYC}, nil
+	case AuthMethodOAuth:
+		if oauthToken != "" {
+			return []AuthSource{AuthSourceOAuthToken}, nil
+		}
+		return []AuthSource{AuthSourceOAuthTokenFile}, nil
+	case AuthMethodServiceAccount:
+		return []AuthSource{AuthSourceServiceAccount}, nil
+	case AuthMethodMetadata:
+		return []AuthSource{AuthSourceMetadata}, nil
+	case AuthMethodChain:
+		return parseAuthChainEnv(rawChain, "YANDEX_AUTH_CHAIN")
+	default:
+		// Unsupported methods are reported by validateAuth.
+		return nil, nil
+	}
+}
+
+// parseAuthChainEnv parses an ordered, duplicate-free list of credential sources.
+func parseAuthChainEnv(rawValue, envName string) ([]AuthSource, error) {
+	items, err := parseCSV(rawValue, envName)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return defaultAuthChain(), nil
+	}
+
+	known := defaultAuthChain()
+	chain := make([]AuthSource, 0, len(items))
+	for _, item := range items {
+		source := AuthSource(strings.ToLower(item))
+		if !slices.Contains(known, source) {
+			return nil, fmt.Errorf("%s: unsupported source %q", envName, item)
+		}
+		if slices.Contains(chain, source) {
+			return nil, fmt.Errorf("%s: duplicate source %q", envName, item)
+		}
+		chain = append(chain, source)
+	}
+
+	return chain, nil
+}
+
+// validateRateLimit checks the rate limit settings of a single service.
+func validateRateLimit(limit float64, burst int, service string) error {
+	if limit < 0 {
+		return fmt.Errorf("YANDEX_%s_RATE_LIMIT must not be negative", service)
+	}
+	if limit > 0 && burst < 1 {
+		return fmt.Errorf("YANDEX_%s_RATE_BURST must be at least 1", service)
+	}
+	return nil
+}
+
+// validateRetry checks the HTTP retry settings.
+func (c *Config) validateRetry() error {
+	var errs []error
+	if c.HTTPRetryMaxAttempts < 1 {
+		errs = append(errs, errors.New("YANDEX_HTTP_RETRY_MAX_ATTEMPTS must be at least 1"))
+	}
+	if c.HTTPRetryBaseDelay <= 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_RETRY_BASE_DELAY_MS must be positive"))
+	}
+	if c.HTTPRetryMaxDelay < c.HTTPRetryBaseDelay {
+		errs = append(errs, errors.New(
+			"YANDEX_HTTP_RETRY_MAX_DELAY_MS must not be less than YANDEX_HTTP_RETRY_BASE_DELAY_MS"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateTransport checks the proxy, TLS and connection pool settings.
+// Certificate files are read when the HTTP client is built.
+func (c *Config) validateTransport() error {
+	var errs []error
+	if c.HTTPProxyURL != "" {
+		if err := validateProxyURL(c.HTTPProxyURL, "YANDEX_HTTP_PROXY"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	files := []struct{ envName, path string }{
+		{envName: "YANDEX_HTTP_CA_FILE", path: c.HTTPCAFile},
+		{envName: "YANDEX_HTTP_CLIENT_CERT_FILE", path: c.HTTPClientCertFile},
+		{envName: "YANDEX_HTTP_CLIENT_KEY_FILE", path: c.HTTPClientKeyFile},
+	}
+	for _, file := range files {
+		if file.path != "" && !filepath.IsAbs(file.path) {
+			errs = append(errs, fmt.Errorf("%s: must be absolute path, got %q", file.envName, file.path))
+		}
+	}
+	if (c.HTTPClientCertFile == "") != (c.HTTPClientKeyFile == "") {
+		errs = append(errs, errors.New(
+			"YANDEX_HTTP_CLIENT_CERT_FILE and YANDEX_HTTP_CLIENT_KEY_FILE must be set together"))
+	}
+	if c.HTTPMaxIdleConns < 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_MAX_IDLE_CONNS must not be negative"))
+	}
+	if c.HTTPMaxIdleConnsPerHost < 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_MAX_IDLE_CONNS_PER_HOST must not be negative"))
+	}
+	if c.HTTPMaxConnsPerHost < 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_MAX_CONNS_PER_HOST must not be negative"))
+	}
+	if c.HTTPIdleConnTimeout <= 0 {
+		errs = append(errs, errors.New("YANDEX_HTTP_IDLE_CONN_TIMEOUT must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateMCPTransport checks the MCP transport and the settings of the streamable HTTP listener.
+func (c *Config) validateMCPTransport() error {
+	switch c.Transport {
+	case MCPTransportStdio:
+		if c.Listen != "" || c.TLSCertFile != "" || c.TLSKeyFile != "" {
+			return errors.New("YANDEX_MCP_LISTEN, YANDEX_MCP_TLS_CERT and YANDEX_MCP_TLS_KEY " +
+				"(--listen, --tls-cert, --tls-key) require the http transport")
+		}
+		return nil
+	case MCPTransportHTTP:
+	default:
+		return fmt.Errorf("YANDEX_MCP_TRANSPORT: unsupported value %q (expected %q or %q)",
+			c.Transport, MCPTransportStdio, MCPTransportHTTP)
+	}
+
+	var errs []error
+	if c.Listen == "" {
+		errs = append(errs, errors.New("the http transport requires YANDEX_MCP_LISTEN (--listen)"))
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, errors.New("YANDEX_MCP_TLS_CERT and YANDEX_MCP_TLS_KEY (--tls-cert, --tls-key) "+
+			"must be set together"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateFixtures checks the HTTP fixtures mode and directory.
+func (c *Config) validateFixtures() error {
+	switch c.HTTPFixturesMode {
+	case FixturesModeOff:
+		return nil
+	case FixturesModeRecord, FixturesModeReplay:
+	default:
+		return fmt.Errorf("YANDEX_HTTP_FIXTURES_MODE: unsupported value %q (expected %q or %q)",
+			c.HTTPFixturesMode, FixturesModeRecord, FixturesModeReplay)
+	}
+	if c.HTTPFixturesDir == "" {
+		return errors.New("YANDEX_HTTP_FIXTURES_DIR is required when YANDEX_HTTP_FIXTURES_MODE is set")
+	}
+	if !filepath.IsAbs(c.HTTPFixturesDir) {
+		return fmt.Errorf("YANDEX_HTTP_FIXTURES_DIR: must be absolute path, got %q", c.HTTPFixturesDir)
+	}
+	return nil
+}
+
+// validateAuditLog checks the audit log path and rotation settings.
+func (c *Config) validateAuditLog() error {
+	var errs []error
+	if c.AuditLogPath != "" && !filepath.IsAbs(c.AuditLogPath) {
+		errs = append(errs, fmt.Errorf("YANDEX_AUDIT_LOG: must be absolute path, got %q", c.AuditLogPath))
+	}
+	if c.AuditLogMaxBytes < 0 {
+		errs = append(errs, errors.New("YANDEX_AUDIT_LOG_MAX_SIZE_MB must not be negative"))
+	}
+	if c.AuditLogMaxBackups < 0 {
+		errs = append(errs, errors.New("YANDEX_AUDIT_LOG_MAX_BACKUPS must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateAuth checks that the selected authentication method has the credentials it needs.
+func (c *Config) validateAuth() error {
+	var errs []error
+
+	switch c.AuthMethod {
+	case AuthMethodYC, AuthMethodMetadata, AuthMethodChain:
+	case AuthMethodOAuth:
+		if c.OAuthToken == "" && c.OAuthTokenFile == "" {
+			errs = append(errs, errors.New(
+				"YANDEX_OAUTH_TOKEN or YANDEX_OAUTH_TOKEN_FILE is required when YANDEX_AUTH_METHOD is oauth"))
+		}
+		if c.OAuthToken != "" && c.OAuthTokenFile != "" {
+			errs = append(errs, errors.New("YANDEX_OAUTH_TOKEN and YANDEX_OAUTH_TOKEN_FILE are mutually exclusive"))
+		}
+	case AuthMethodServiceAccount:
+		if c.ServiceAccountKeyFile == "" {
+			errs = append(errs, errors.New("YANDEX_SA_KEY_FILE is required when YANDEX_AUTH_METHOD is service_account"))
+		}
+	default:
+		return fmt.Errorf("YANDEX_AUTH_METHOD: unsupported value %q (expected one of %q, %q, %q, %q, %q)",
+			c.AuthMethod, AuthMethodYC, AuthMethodOAuth, AuthMethodServiceAccount, AuthMethodMetadata, AuthMethodChain)
+	}
+
+	for _, source := range c.AuthChain {
+		if err := c.validateAuthSource(source); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateAuthSource checks settings used by a single credential source.
+// Missing optional credentials are allowed here: the chain skips unconfigured sources.
+func (c *Config) validateAuthSource(source AuthSource) error {
+	switch source {
+	case AuthSourceOAuthToken:
+		return nil
+	case AuthSourceYC:
+		return c.validateYC()
+	case AuthSourceOAuthTokenFile:
+		if c.OAuthTokenFile != "" && !filepath.IsAbs(c.OAuthTokenFile) {
+			return fmt.Errorf("YANDEX_OAUTH_TOKEN_FILE: must be absolute path, got %q", c.OAuthTokenFile)
+		}
+		return nil
+	case AuthSourceServiceAccount:
+		var errs []error
+		if c.ServiceAccountKeyFile != "" && !filepath.IsAbs(c.ServiceAccountKeyFile) {
+			errs = append(errs, fmt.Errorf("YANDEX_SA_KEY_FILE: must be absolute path, got %q", c.ServiceAccountKeyFile))
+		}
+		if err := validateEndpointURL(c.IAMEndpoint, "YANDEX_IAM_ENDPOINT"); err != nil {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	case AuthSourceMetadata:
+		return validateEndpointURL(c.MetadataURL, "YANDEX_METADATA_URL")
+	default:
+		return fmt.Errorf("unsupported auth source %q", source)
+	}
+}
+
+// validateYC checks yc CLI invocation settings.
+func (c *Config) validateYC() error {
+	var errs []error
+
+	if c.YCPath != defaultYCCommand && !filepath.IsAbs(c.YCPath) {
+		errs = append(errs, fmt.Errorf(
+			"YANDEX_YC_PATH: must be %q or an absolute path, got %q", defaultYCCommand, c.YCPath,
+		))
+	}
+	if c.YCProfile != "" && !isValidProfileName(c.YCProfile) {
+		errs = append(errs, fmt.Errorf("YANDEX_YC_PROFILE: invalid profile name %q", c.YCProfile))
+	}
+	if c.YCTimeout <= 0 {
+		errs = append(errs, errors.New("YANDEX_YC_TIMEOUT must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// isValidProfileName rejects profile names that could be interpreted as CLI flags or contain unexpected characters.
+func isValidProfileName(name string) bool {
+	if strings.HasPrefix(name, "-") {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func validateHTTPSURL(rawURL, envName string) error {
 	parsed, err := url.Parse(rawURL)
 	if err != nil {
@@ -298,3 +1004,75 @@ func validateHTTPSURL(rawURL, envName string) error {
 
 	return nil
 }
+
+// validateProxyURL accepts http, https and socks5 proxy URLs with a host.
+func validateProxyURL(rawURL, envName string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%s: invalid URL: %w", envName, err)
+	}
+
+	switch parsed.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("%s: must use http, https or socks5 scheme, got %q", envName, parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("%s: missing host", envName)
+	}
+
+	return nil
+}
+
+// RedactedHTTPProxyURL returns the proxy URL with its password hidden, for reports shared by users.
+func (c *Config) RedactedHTTPProxyURL() string {
+	return redactURL(c.HTTPProxyURL)
+}
+
+// redactURL hides the password of a URL; a value that does not parse is masked entirely.
+func redactURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return masked
+	}
+	return parsed.Redacted()
+}
+
+// validateEndpointURL requires https, allowing plain http only for loopback hosts (local stand-ins)
+// and link-local addresses (the compute metadata service).
+func validateEndpointURL(rawURL, envName string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%s: invalid URL: %w", envName, err)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("%s: missing host", envName)
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLocalHost(parsed.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("%s: http scheme is allowed only for loopback or link-local hosts, got %q",
+			envName, parsed.Hostname())
+	default:
+		return fmt.Errorf("%s: must use https scheme, got %q", envName, parsed.Scheme)
+	}
+}
+
+// isLocalHost reports whether host refers to the local machine or a link-local address.
+func isLocalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsLinkLocalUnicast())
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+// configDirName is the directory of the default configuration file under the user configuration directory.
+const configDirName = "yandex-mcp"
+
+// defaultFileNames lists the configuration file names looked up in the default location, in order.
+func defaultFileNames() []string {
+	return []string{"config.yaml", "config.yml", "config.toml"}
+}
+
+// DefaultFile returns the first existing configuration file in the default location
+// (e.g. ~/.config/yandex-mcp/config.yaml on Linux), or an empty string when there is none.
+func DefaultFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		// without a home directory there is no default location to look at
+		return "", nil //nolint:nilerr // a missing default location is not an error
+	}
+
+	for _, name := range defaultFileNames() {
+		path := filepath.Join(configDir, configDirName, name)
+		_, err = os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	return "", nil
+}
+
+// readFile reads a YAML or TOML configuration file and returns its settings keyed by environment variable name.
+// Keys are the variable names without the YANDEX_ prefix, in any case; nested tables join their keys with "_",
+// so "http: {timeout: 30}" sets YANDEX_HTTP_TIMEOUT. Lists are joined with commas.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var document map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		err = toml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err = flattenFile(document, strings.TrimSuffix(settingEnvPrefix, "_"), values); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	known, err := knownEnvKeys()
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for key := range values {
+		if !slices.Contains(known, key) && !strings.HasPrefix(key, orgProfileEnvPrefix) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("config file %s: unknown settings %s", path, strings.Join(unknown, ", "))
+	}
+
+	return values, nil
+}
+
+// flattenFile converts nested file tables into environment variable names under the prefix.
+func flattenFile(table map[string]any, prefix string, values map[string]string) error {
+	for key, value := range table {
+		name := prefix + "_" + strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(key), "-", "_"))
+
+		if nested, ok := value.(map[string]any); ok {
+			if err := flattenFile(nested, name, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		formatted, err := formatFileValue(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if _, ok := values[name]; ok {
+			return fmt.Errorf("%s is set more than once", name)
+		}
+		values[name] = formatted
+	}
+	return nil
+}
+
+// formatFileValue converts a scalar or a list of scalars into the environment variable form.
+func formatFileValue(value any) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if _, isList := item.([]any); isList {
+				return "", errors.New("nested lists are not supported")
+			}
+			formatted, err := formatFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			// list settings are parsed as comma-separated values, which cannot be escaped
+			if strings.Contains(formatted, ",") {
+				return "", fmt.Errorf("list item %q must not contain a comma", formatted)
+			}
+			items = append(items, formatted)
+		}
+		return strings.Join(items, ","), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// knownEnvKeys lists the environment variables of all settings except per-profile overrides.
+func knownEnvKeys() ([]string, error) {
+	params, err := env.GetFieldParams(&envConfig{}) //nolint:exhaustruct // only the tags are inspected
+	if err != nil {
+		return nil, fmt.Errorf("inspect settings: %w", err)
+	}
+	keys := make([]string, 0, len(params)+1)
+	for _, param := range params {
+		keys = append(keys, param.Key)
+	}
+	return append(keys, orgProfilesEnv), nil
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+)
+
+// Source is the configuration layer a setting value comes from.
+type Source string
+
+// Configuration layers, from the lowest to the highest precedence.
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// masked replaces values of secret settings in the effective configuration.
+const masked = "********"
+
+// Options selects the configuration layers around the environment: file < env < flags.
+type Options struct {
+	// File is the YAML or TOML configuration file; empty uses DefaultFile.
+	File string
+	// Flags holds command line overrides keyed by environment variable name.
+	Flags map[string]string
+}
+
+// Setting is an effective configuration value with its origin.
+type Setting struct {
+	// Key is the environment variable name of the setting.
+	Key    string
+	Value  string
+	Source Source
+}
+
+// Effective is the merged configuration input of all layers.
+type Effective struct {
+	// File is the configuration file that was read; empty when there is none.
+	File     string
+	Settings []Setting
+
+	environ map[string]string
+}
+
+// LoadWithOptions loads the configuration from the file, the environment and the flags and validates it.
+func LoadWithOptions(opts Options) (*Config, error) {
+	effective, err := Resolve(opts)
+	if err != nil {
+		return nil, err
+	}
+	return effective.Load()
+}
+
+// Resolve merges the configuration layers without validating the values.
+func Resolve(opts Options) (*Effective, error) {
+	path := opts.File
+	if path == "" {
+		var err error
+		if path, err = DefaultFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	fileValues := map[string]string{}
+	if path != "" {
+		var err error
+		if fileValues, err = readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	processEnv := env.ToMap(os.Environ())
+	environ := make(map[string]string, len(fileValues)+len(processEnv)+len(opts.Flags))
+	sources := make(map[string]Source, len(environ))
+	for _, layer := range []struct {
+		values map[string]string
+		source Source
+	}{
+		{values: fileValues, source: SourceFile},
+		{values: processEnv, source: SourceEnv},
+		{values: opts.Flags, source: SourceFlag},
+	} {
+		for key, value := range layer.values {
+			environ[key] = value
+			sources[key] = layer.source
+		}
+	}
+
+	settings, err := effectiveSettings(environ, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Effective{
+		File:     path,
+		Settings: settings,
+		environ:  environ,
+	}, nil
+}
+
+// Load parses and validates the merged configuration.
+func (e *Effective) Load() (*Config, error) {
+	cfg, err := loadEnviron(e.environ)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.OrgProfiles, err = loadOrgProfiles(e.environ, cfg.OrgName)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ConfigFile = e.File
+
+	return cfg, nil
+}
+
+// effectiveSettings lists every known setting and every per-profile override in stable order,
+// with defaults for unset settings, secrets masked and proxy passwords hidden.
+func effectiveSettings(environ map[string]string, sources map[string]Source) ([]Setting, error) {
+	params, err := env.GetFieldParams(&envConfig{}) //nolint:exhaustruct // only the tags are inspected
+	if err != nil {
+		return nil, err
+	}
+	defaults := make(map[string]string, len(params))
+	keys := make([]string, 0, len(params)+1)
+	for _, param := range params {
+		keys = append(keys, param.Key)
+		defaults[param.Key] = param.DefaultValue
+	}
+	keys = append(keys, orgProfilesEnv)
+	for _, key := range slices.Sorted(maps.Keys(environ)) {
+		if strings.HasPrefix(key, orgProfileEnvPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		setting := Setting{Key: key, Value: defaults[key], Source: SourceDefault}
+		if value, ok := environ[key]; ok {
+			setting.Value = value
+			setting.Source = sources[key]
+		}
+		switch {
+		case setting.Value == "":
+		case isSecretSetting(key):
+			setting.Value = masked
+		case isProxySetting(key):
+			setting.Value = redactURL(setting.Value)
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// secretSettings lists settings (without the YANDEX_ prefix) that hold a credential rather than a reference to one.
+// A new setting with a credential value has to be added here to be masked.
+func secretSettings() []string {
+	return []string{
+		"OAUTH_TOKEN",
+	}
+}
+
+// isSecretSetting reports whether the environment variable sets one of the secret settings.
+func isSecretSetting(key string) bool {
+	return slices.Contains(secretSettings(), envKeySetting(key))
+}
+
+// isProxySetting reports whether the setting is a proxy URL, which may embed the proxy password.
+func isProxySetting(key string) bool {
+	return envKeySetting(key) == "HTTP_PROXY"
+}
+
+// envKeySetting returns the setting set by the environment variable without the YANDEX_ prefix,
+// e.g. HTTP_PROXY for YANDEX_HTTP_PROXY and YANDEX_ORG_PROFILE_TEAM_HTTP_PROXY. The setting of a per-profile key
+// is the longest overridable setting it ends with; an empty string is returned if there is none.
+func envKeySetting(key string) string {
+	rest, isProfileKey := strings.CutPrefix(key, orgProfileEnvPrefix)
+	if !isProfileKey {
+		return strings.TrimPrefix(key, settingEnvPrefix)
+	}
+
+	var setting string
+	for _, candidate := range orgProfileSettings() {
+		if strings.HasSuffix(rest, "_"+candidate) && len(candidate) > len(setting) {
+			setting = candidate
+		}
+	}
+	return setting
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// orgProfilesEnv lists the names of additional organization profiles.
+	orgProfilesEnv = "YANDEX_ORG_PROFILES"
+	// orgProfileEnvPrefix starts per-profile overrides: YANDEX_ORG_PROFILE_<NAME>_<SETTING> replaces YANDEX_<SETTING>.
+	orgProfileEnvPrefix = "YANDEX_ORG_PROFILE_"
+	// settingEnvPrefix is the common prefix of settings that can be overridden per profile.
+	settingEnvPrefix = "YANDEX_"
+)
+
+// orgProfileSettings lists settings (without the YANDEX_ prefix) that an organization profile may override.
+func orgProfileSettings() []string {
+	return append([]string{
+		"CLOUD_ORG_ID",
+		"ORG_TYPE",
+		"WIKI_BASE_URL",
+		"TRACKER_BASE_URL",
+		"HTTP_TIMEOUT",
+		"HTTP_PROXY",
+		"HTTP_CA_FILE",
+		"HTTP_CLIENT_CERT_FILE",
+		"HTTP_CLIENT_KEY_FILE",
+		"TRACKER_RATE_LIMIT",
+		"TRACKER_RATE_BURST",
+		"WIKI_RATE_LIMIT",
+		"WIKI_RATE_BURST",
+	}, orgProfileAuthSettings()...)
+}
+
+// orgProfileAuthSettings lists the profile settings that affect token acquisition.
+func orgProfileAuthSettings() []string {
+	return []string{
+		"AUTH_METHOD",
+		"AUTH_CHAIN",
+		"OAUTH_TOKEN",
+		"OAUTH_TOKEN_FILE",
+		"SA_KEY_FILE",
+		"IAM_ENDPOINT",
+		"METADATA_URL",
+		"YC_PATH",
+		"YC_PROFILE",
+		"YC_TIMEOUT",
+		"IAM_TOKEN_REFRESH_PERIOD",
+	}
+}
+
+// loadOrgProfiles loads the additional organization profiles listed in YANDEX_ORG_PROFILES.
+// Each profile starts from the primary environment and applies its own YANDEX_ORG_PROFILE_<NAME>_* overrides.
+func loadOrgProfiles(environ map[string]string, primaryName string) ([]*Config, error) {
+	names, err := parseOrgProfileNames(environ[orgProfilesEnv], primaryName)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]string, 0, len(names))
+	for _, name := range names {
+		prefixes = append(prefixes, orgProfileEnvKeyPrefix(name))
+	}
+
+	if err := checkOrgProfileKeys(environ, prefixes); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	profiles := make([]*Config, 0, len(names))
+	for i, name := range names {
+		profile, err := loadOrgProfile(environ, name, prefixes[i])
+		if err != nil {
+			return nil, fmt.Errorf("org profile %q: %w", name, err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// loadOrgProfile loads a single organization profile from the primary environment and its overrides.
+func loadOrgProfile(environ map[string]string, name, prefix string) (*Config, error) {
+	if strings.TrimSpace(environ[prefix+"CLOUD_ORG_ID"]) == "" {
+		return nil, fmt.Errorf("%sCLOUD_ORG_ID is required", prefix)
+	}
+
+	profileEnv := maps.Clone(environ)
+	inheritsAuth := true
+	for _, setting := range orgProfileSettings() {
+		value, ok := environ[prefix+setting]
+		if !ok {
+			continue
+		}
+		profileEnv[settingEnvPrefix+setting] = value
+		if slices.Contains(orgProfileAuthSettings(), setting) {
+			inheritsAuth = false
+		}
+	}
+	profileEnv["YANDEX_ORG_NAME"] = name
+
+	profile, err := loadEnviron(profileEnv)
+	if err != nil {
+		return nil, err
+	}
+	profile.InheritsAuth = inheritsAuth
+
+	return profile, nil
+}
+
+// parseOrgProfileNames validates additional profile names against each other and the primary profile name.
+func parseOrgProfileNames(rawValue, primaryName string) ([]string, error) {
+	items, err := parseCSV(rawValue, orgProfilesEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	seenKeys := map[string]string{orgProfileEnvKeyPrefix(primaryName): primaryName}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		name := strings.ToLower(item)
+		if !isValidOrgName(name) {
+			errs = append(errs, fmt.Errorf("%s: invalid profile name %q", orgProfilesEnv, item))
+			continue
+		}
+		key := orgProfileEnvKeyPrefix(name)
+		if other, ok := seenKeys[key]; ok {
+			errs = append(errs, fmt.Errorf("%s: profile name %q conflicts with %q", orgProfilesEnv, item, other))
+			continue
+		}
+		seenKeys[key] = name
+		names = append(names, name)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// checkOrgProfileKeys rejects unknown per-profile settings and settings of profiles that are not listed,
+// so typos do not silently fall back to the primary configuration.
+func checkOrgProfileKeys(environ map[string]string, prefixes []string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(environ)) {
+		if !strings.HasPrefix(key, orgProfileEnvPrefix) {
+			continue
+		}
+
+		prefix := longestMatchingPrefix(key, prefixes)
+		if prefix == "" {
+			errs = append(errs, fmt.Errorf("%s: profile is not listed in %s", key, orgProfilesEnv))
+			continue
+		}
+		if !slices.Contains(orgProfileSettings(), strings.TrimPrefix(key, prefix)) {
+			errs = append(errs, fmt.Errorf("%s: setting cannot be overridden per profile", key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// longestMatchingPrefix picks the most specific profile prefix so that profile "a" does not claim keys of "a_b".
+func longestMatchingPrefix(key string, prefixes []string) string {
+	var best string
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	return best
+}
+
+// SettingEnvKey returns the environment variable that sets the setting (e.g. "CLOUD_ORG_ID")
+// for the primary profile or, when primary is false, for the named additional profile.
+func SettingEnvKey(orgName string, primary bool, setting string) string {
+	if primary {
+		return settingEnvPrefix + setting
+	}
+	return orgProfileEnvKeyPrefix(orgName) + setting
+}
+
+// orgProfileEnvKeyPrefix returns the environment variable prefix for the profile overrides.
+func orgProfileEnvKeyPrefix(name string) string {
+	return orgProfileEnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// isValidOrgName accepts lowercase profile names that map to environment variable names unambiguously.
+func isValidOrgName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9':
+		case r == '-' || r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
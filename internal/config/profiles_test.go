@@ -0,0 +1,177 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_NoOrgProfiles(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "default", cfg.OrgName)
+	assert.Empty(t, cfg.OrgProfiles)
+}
+
+func TestLoad_OrgProfilesInheritUnsetSettings(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+	t.Setenv("YANDEX_ORG_NAME", "Own")
+	t.Setenv("YANDEX_TRACKER_BASE_URL", "https://tracker.example.com")
+	t.Setenv("YANDEX_ORG_PROFILES", "customer")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID", "org-customer")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_ORG_TYPE", "360")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "own", cfg.OrgName)
+	require.Len(t, cfg.OrgProfiles, 1)
+
+	profile := cfg.OrgProfiles[0]
+	assert.Equal(t, "customer", profile.OrgName)
+	assert.Equal(t, "org-customer", profile.CloudOrgID)
+	assert.Equal(t, OrgType360, profile.OrgType)
+	assert.Equal(t, "https://tracker.example.com", profile.TrackerBaseURL)
+	assert.Equal(t, cfg.AuthMethod, profile.AuthMethod)
+	assert.True(t, profile.InheritsAuth)
+	assert.Empty(t, profile.OrgProfiles)
+}
+
+func TestLoad_OrgProfileOwnAuth(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+	t.Setenv("YANDEX_ORG_PROFILES", "customer-b")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_B_CLOUD_ORG_ID", "org-customer")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_B_AUTH_METHOD", "oauth")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_B_OAUTH_TOKEN", "y0_customer")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, AuthMethodYC, cfg.AuthMethod)
+	assert.Empty(t, cfg.OAuthToken)
+	require.Len(t, cfg.OrgProfiles, 1)
+
+	profile := cfg.OrgProfiles[0]
+	assert.Equal(t, "customer-b", profile.OrgName)
+	assert.Equal(t, AuthMethodOAuth, profile.AuthMethod)
+	assert.Equal(t, "y0_customer", profile.OAuthToken)
+	assert.False(t, profile.InheritsAuth)
+}
+
+func TestLoad_OrgProfileEmptyOverrideClearsInheritedValue(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+	t.Setenv("YANDEX_AUTH_METHOD", "oauth")
+	t.Setenv("YANDEX_OAUTH_TOKEN", "y0_own")
+	t.Setenv("YANDEX_ORG_PROFILES", "customer")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID", "org-customer")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_OAUTH_TOKEN", "")
+	t.Setenv("YANDEX_ORG_PROFILE_CUSTOMER_OAUTH_TOKEN_FILE", "/run/secrets/customer-token")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	require.Len(t, cfg.OrgProfiles, 1)
+	assert.Empty(t, cfg.OrgProfiles[0].OAuthToken)
+	assert.Equal(t, "/run/secrets/customer-token", cfg.OrgProfiles[0].OAuthTokenFile)
+}
+
+func TestLoad_OrgProfilesSimilarNames(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+	t.Setenv("YANDEX_ORG_PROFILES", "a,a_b")
+	t.Setenv("YANDEX_ORG_PROFILE_A_CLOUD_ORG_ID", "org-a")
+	t.Setenv("YANDEX_ORG_PROFILE_A_B_CLOUD_ORG_ID", "org-a-b")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	require.Len(t, cfg.OrgProfiles, 2)
+	assert.Equal(t, "org-a", cfg.OrgProfiles[0].CloudOrgID)
+	assert.Equal(t, "org-a-b", cfg.OrgProfiles[1].CloudOrgID)
+}
+
+func TestLoad_OrgProfilesValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "missing org id",
+			env:     map[string]string{"YANDEX_ORG_PROFILES": "customer"},
+			wantErr: "YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID is required",
+		},
+		{
+			name:    "invalid name",
+			env:     map[string]string{"YANDEX_ORG_PROFILES": "our customer"},
+			wantErr: "invalid profile name",
+		},
+		{
+			name:    "duplicate of primary",
+			env:     map[string]string{"YANDEX_ORG_PROFILES": "default"},
+			wantErr: "conflicts with",
+		},
+		{
+			name:    "names mapping to same variables",
+			env:     map[string]string{"YANDEX_ORG_PROFILES": "a-b,a_b"},
+			wantErr: "conflicts with",
+		},
+		{
+			name: "unknown setting",
+			env: map[string]string{
+				"YANDEX_ORG_PROFILES":                        "customer",
+				"YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID":   "org-customer",
+				"YANDEX_ORG_PROFILE_CUSTOMER_MCP_ATTACH_EXT": "txt",
+			},
+			wantErr: "cannot be overridden per profile",
+		},
+		{
+			name:    "unlisted profile",
+			env:     map[string]string{"YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID": "org-customer"},
+			wantErr: "profile is not listed",
+		},
+		{
+			name: "invalid profile setting",
+			env: map[string]string{
+				"YANDEX_ORG_PROFILES":                      "customer",
+				"YANDEX_ORG_PROFILE_CUSTOMER_CLOUD_ORG_ID": "org-customer",
+				"YANDEX_ORG_PROFILE_CUSTOMER_ORG_TYPE":     "business",
+			},
+			wantErr: `org profile "customer"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			assert.Contains(t, err.Error(), testCase.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidOrgName(t *testing.T) {
+	t.Setenv("YANDEX_CLOUD_ORG_ID", "org-own")
+	t.Setenv("YANDEX_ORG_NAME", "own org")
+
+	cfg, err := Load()
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "YANDEX_ORG_NAME")
+}
+
+func TestSettingEnvKey(t *testing.T) {
+	assert.Equal(t, "YANDEX_CLOUD_ORG_ID", SettingEnvKey("own", true, "CLOUD_ORG_ID"))
+	assert.Equal(t, "YANDEX_ORG_PROFILE_TEAM_B_CLOUD_ORG_ID", SettingEnvKey("team-b", false, "CLOUD_ORG_ID"))
+}
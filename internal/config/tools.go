@@ -0,0 +1,169 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// ToolSelection selects the tools registered with the MCP server.
+type ToolSelection struct {
+	// Services lists the services whose tools are registered.
+	Services []domain.Service
+	// Allow lists glob patterns of tool names to register; empty registers every tool of the enabled services.
+	Allow []string
+	// Deny lists glob patterns of tool names not to register; it takes precedence over Allow.
+	Deny []string
+}
+
+// TrackerTools returns the selected Tracker tools in stable order.
+func (s ToolSelection) TrackerTools() []domain.TrackerTool {
+	if !slices.Contains(s.Services, domain.ServiceTracker) {
+		return nil
+	}
+	var tools []domain.TrackerTool
+	for _, tool := range domain.TrackerAllTools() {
+		if s.selected(tool.String()) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// WikiTools returns the selected Wiki tools in stable order.
+func (s ToolSelection) WikiTools() []domain.WikiTool {
+	if !slices.Contains(s.Services, domain.ServiceWiki) {
+		return nil
+	}
+	var tools []domain.WikiTool
+	for _, tool := range domain.WikiAllTools() {
+		if s.selected(tool.String()) {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// AuthDiagnosticsEnabled reports whether the auth_diagnostics tool is selected. The tool belongs to no service,
+// so only the allowlist and the denylist apply to it.
+func (s ToolSelection) AuthDiagnosticsEnabled() bool {
+	return s.selected(domain.DiagnosticsToolAuth)
+}
+
+// selected reports whether the tool name passes the allowlist and the denylist.
+func (s ToolSelection) selected(name string) bool {
+	if matchesAny(s.Deny, name) {
+		return false
+	}
+	return len(s.Allow) == 0 || matchesAny(s.Allow, name)
+}
+
+// validate rejects unknown services, malformed patterns, patterns matching no tool and an empty selection.
+func (s ToolSelection) validate() error {
+	var errs []error
+
+	var unknownServices []string
+	for _, service := range s.Services {
+		if service != domain.ServiceTracker && service != domain.ServiceWiki {
+			unknownServices = append(unknownServices, string(service))
+		}
+	}
+	if len(unknownServices) > 0 {
+		errs = append(errs, fmt.Errorf("YANDEX_MCP_SERVICES: unknown services %s (expected %q or %q)",
+			strings.Join(unknownServices, ", "), domain.ServiceTracker, domain.ServiceWiki))
+	}
+
+	names := toolNames()
+	if err := validateToolPatterns("YANDEX_MCP_TOOLS_ALLOW", s.Allow, names); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateToolPatterns("YANDEX_MCP_TOOLS_DENY", s.Deny, names); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 && len(s.TrackerTools())+len(s.WikiTools()) == 0 {
+		errs = append(errs, errors.New(
+			"YANDEX_MCP_SERVICES, YANDEX_MCP_TOOLS_ALLOW and YANDEX_MCP_TOOLS_DENY leave no Tracker or Wiki tools enabled"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateToolPatterns checks that every pattern is a valid glob matching at least one tool name.
+// All invalid and unknown patterns are reported at once.
+func validateToolPatterns(envName string, patterns, names []string) error {
+	var invalid, unknown []string
+	for i, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			invalid = append(invalid, strconv.Quote(pattern))
+			continue
+		}
+		if !slices.ContainsFunc(names, func(name string) bool { return matchesAny(patterns[i:i+1], name) }) {
+			unknown = append(unknown, pattern)
+		}
+	}
+
+	var errs []error
+	if len(invalid) > 0 {
+		errs = append(errs, fmt.Errorf("%s: invalid patterns %s: %w",
+			envName, strings.Join(invalid, ", "), path.ErrBadPattern))
+	}
+	if len(unknown) > 0 {
+		errs = append(errs, fmt.Errorf("%s: unknown tools %s", envName, strings.Join(unknown, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// matchesAny reports whether the tool name matches one of the glob patterns. Patterns are validated at load.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// toolNames returns the names of all Tracker, Wiki and diagnostics tools.
+func toolNames() []string {
+	names := make([]string, 0, int(domain.TrackerToolCount)+int(domain.WikiToolCount)+1)
+	names = append(names, domain.DiagnosticsToolAuth)
+	for _, tool := range domain.TrackerAllTools() {
+		names = append(names, tool.String())
+	}
+	for _, tool := range domain.WikiAllTools() {
+		names = append(names, tool.String())
+	}
+	return names
+}
+
+// parseToolSelection parses the enabled services and the tool name patterns.
+func parseToolSelection(ec envConfig) (ToolSelection, error) {
+	services, err := parseCSV(ec.Services, "YANDEX_MCP_SERVICES")
+	if err != nil {
+		return ToolSelection{}, err //nolint:exhaustruct // zero value is unused on error
+	}
+	allow, err := parseCSV(ec.ToolsAllow, "YANDEX_MCP_TOOLS_ALLOW")
+	if err != nil {
+		return ToolSelection{}, err //nolint:exhaustruct // see above
+	}
+	deny, err := parseCSV(ec.ToolsDeny, "YANDEX_MCP_TOOLS_DENY")
+	if err != nil {
+		return ToolSelection{}, err //nolint:exhaustruct // see above
+	}
+
+	selection := ToolSelection{
+		Services: make([]domain.Service, 0, len(services)),
+		Allow:    allow,
+		Deny:     deny,
+	}
+	for _, service := range services {
+		selection.Services = append(selection.Services, domain.Service(strings.ToLower(service)))
+	}
+	return selection, nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_ToolSelection(t *testing.T) {
+	t.Run("all tools by default", func(t *testing.T) {
+		t.Setenv("YANDEX_CLOUD_ORG_ID", "test-org")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.TrackerAllTools(), cfg.Tools.TrackerTools())
+		assert.Equal(t, domain.WikiAllTools(), cfg.Tools.WikiTools())
+		assert.True(t, cfg.Tools.AuthDiagnosticsEnabled())
+	})
+
+	t.Run("wiki only", func(t *testing.T) {
+		t.Setenv("YANDEX_CLOUD_ORG_ID", "test-org")
+		t.Setenv("YANDEX_MCP_SERVICES", " Wiki ")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Tools.TrackerTools())
+		assert.Equal(t, domain.WikiAllTools(), cfg.Tools.WikiTools())
+	})
+
+	t.Run("allowlist and denylist", func(t *testing.T) {
+		t.Setenv("YANDEX_CLOUD_ORG_ID", "test-org")
+		t.Setenv("YANDEX_MCP_TOOLS_ALLOW", "tracker_issue_*, wiki_page_get")
+		t.Setenv("YANDEX_MCP_TOOLS_DENY", "tracker_issue_attachment*,tracker_issue_changelog")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TrackerTool{
+			domain.TrackerToolIssueGet,
+			domain.TrackerToolIssueSearch,
+			domain.TrackerToolIssueCount,
+			domain.TrackerToolTransitionsList,
+			domain.TrackerToolCommentsList,
+			domain.TrackerToolLinksList,
+		}, cfg.Tools.TrackerTools())
+		assert.Equal(t, []domain.WikiTool{domain.WikiToolPageGetBySlug}, cfg.Tools.WikiTools())
+		assert.False(t, cfg.Tools.AuthDiagnosticsEnabled(), "an allowlist without it excludes auth_diagnostics")
+	})
+
+	t.Run("diagnostics selected by name", func(t *testing.T) {
+		t.Setenv("YANDEX_CLOUD_ORG_ID", "test-org")
+		t.Setenv("YANDEX_MCP_SERVICES", "wiki")
+		t.Setenv("YANDEX_MCP_TOOLS_ALLOW", "wiki_*,auth_diagnostics")
+
+		cfg, err := Load()
+
+		require.NoError(t, err)
+		assert.True(t, cfg.Tools.AuthDiagnosticsEnabled(), "services do not apply to auth_diagnostics")
+
+		t.Setenv("YANDEX_MCP_TOOLS_DENY", "auth_*")
+
+		cfg, err = Load()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.Tools.AuthDiagnosticsEnabled())
+	})
+
+	testCases := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown service",
+			env:     map[string]string{"YANDEX_MCP_SERVICES": "tracker,forms"},
+			wantErr: "YANDEX_MCP_SERVICES: unknown services forms",
+		},
+		{
+			name:    "unknown allowed tools",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_ALLOW": "tracker_issue_get,tracker_issue_gett,forms_*"},
+			wantErr: "YANDEX_MCP_TOOLS_ALLOW: unknown tools tracker_issue_gett, forms_*",
+		},
+		{
+			name:    "unknown denied tool",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_DENY": "wiki_page_delete"},
+			wantErr: "YANDEX_MCP_TOOLS_DENY: unknown tools wiki_page_delete",
+		},
+		{
+			name:    "invalid pattern",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_ALLOW": "tracker_[issue"},
+			wantErr: "YANDEX_MCP_TOOLS_ALLOW: invalid pattern",
+		},
+		{
+			name:    "all invalid patterns",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_ALLOW": "tracker_[issue,forms_*,wiki_[page"},
+			wantErr: `YANDEX_MCP_TOOLS_ALLOW: invalid patterns "tracker_[issue", "wiki_[page"`,
+		},
+		{
+			name:    "unknown tools next to invalid patterns",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_ALLOW": "tracker_[issue,forms_*,wiki_[page"},
+			wantErr: "YANDEX_MCP_TOOLS_ALLOW: unknown tools forms_*",
+		},
+		{
+			name:    "empty list item",
+			env:     map[string]string{"YANDEX_MCP_TOOLS_DENY": "wiki_*,"},
+			wantErr: "YANDEX_MCP_TOOLS_DENY: empty value is not allowed",
+		},
+		{
+			name: "no tools left",
+			env: map[string]string{
+				"YANDEX_MCP_SERVICES":    "wiki",
+				"YANDEX_MCP_TOOLS_ALLOW": "tracker_*",
+			},
+			wantErr: "leave no Tracker or Wiki tools enabled",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("YANDEX_CLOUD_ORG_ID", "test-org")
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			assert.Contains(t, err.Error(), testCase.wantErr)
+		})
+	}
+}
@@ -0,0 +1,440 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// OrgClients are the clients used to verify a single organization profile.
+type OrgClients struct {
+	TokenProvider ITokenProvider
+	Tracker       ITrackerClient
+	Wiki          IWikiClient
+}
+
+// ClientFactory creates the clients for an organization profile.
+type ClientFactory func(profile *config.Config) (*OrgClients, error)
+
+// Doctor runs the setup checks.
+type Doctor struct {
+	loadConfig func() (*config.Config, error)
+	newClients ClientFactory
+}
+
+// New creates a Doctor that loads the configuration with loadConfig
+// and verifies every organization profile with clients from newClients.
+func New(loadConfig func() (*config.Config, error), newClients ClientFactory) *Doctor {
+	return &Doctor{
+		loadConfig: loadConfig,
+		newClients: newClients,
+	}
+}
+
+// Run performs all checks and returns the report.
+func (d *Doctor) Run(ctx context.Context) *Report {
+	report := &Report{
+		Sections: nil,
+		Checks:   nil,
+	}
+
+	cfg, err := d.loadConfig()
+	if err != nil {
+		report.Checks = append(report.Checks, Check{
+			Name:    "configuration",
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    configHint(err),
+		})
+		return report
+	}
+
+	profiles := append([]*config.Config{cfg}, cfg.OrgProfiles...)
+	for _, profile := range profiles {
+		report.Sections = append(report.Sections, profileSection(profile, profile == cfg))
+	}
+	report.Sections = append(report.Sections, attachmentSection(cfg))
+
+	report.Checks = append(report.Checks, Check{
+		Name:    "configuration",
+		Status:  StatusPass,
+		Details: fmt.Sprintf("loaded %d organization profile(s)", len(profiles)),
+		Hint:    "",
+	})
+	report.Checks = append(report.Checks, checkAttachmentDirs(cfg)...)
+
+	for _, profile := range profiles {
+		report.Checks = append(report.Checks, d.checkProfile(ctx, profile, profile == cfg)...)
+	}
+
+	return report
+}
+
+// checkProfile verifies token acquisition and API access for a single organization profile.
+func (d *Doctor) checkProfile(ctx context.Context, profile *config.Config, primary bool) []Check {
+	prefix := profile.OrgName + ": "
+
+	clients, err := d.newClients(profile)
+	if err != nil {
+		return []Check{{
+			Name:    prefix + "auth",
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    tokenHint(err.Error(), profile, primary),
+		}}
+	}
+
+	checks := []Check{checkToken(ctx, clients.TokenProvider, profile, primary)}
+	if checks[0].Status == StatusFail {
+		return append(checks,
+			skippedCheck(prefix+"tracker"),
+			skippedCheck(prefix+"wiki"),
+		)
+	}
+
+	return append(checks,
+		checkTracker(ctx, clients.Tracker, profile, primary),
+		checkWiki(ctx, clients.Wiki, profile, primary),
+	)
+}
+
+// checkToken acquires a token and reports the credential source that provided it.
+func checkToken(ctx context.Context, provider ITokenProvider, profile *config.Config, primary bool) Check {
+	name := profile.OrgName + ": token"
+
+	_, err := provider.Token(ctx, false)
+	diagnostics := provider.AuthDiagnostics()
+	if err != nil {
+		reasons := []string{err.Error()}
+		for _, attempt := range diagnostics.Attempts {
+			reasons = append(reasons, attempt.Reason)
+		}
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    tokenHint(strings.Join(reasons, "; "), profile, primary),
+		}
+	}
+
+	return Check{
+		Name:    name,
+		Status:  StatusPass,
+		Details: "acquired via " + diagnostics.SelectedSource,
+		Hint:    "",
+	}
+}
+
+// checkTracker calls /v3/myself to verify the token and the organization binding.
+func checkTracker(ctx context.Context, client ITrackerClient, profile *config.Config, primary bool) Check {
+	name := profile.OrgName + ": tracker"
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    apiHint(err, domain.ServiceTracker, profile, primary),
+		}
+	}
+
+	return Check{
+		Name:    name,
+		Status:  StatusPass,
+		Details: fmt.Sprintf("authenticated as %s (%s)", user.Login, user.Display),
+		Hint:    "",
+	}
+}
+
+// wikiProbeSlug is the Wiki root page; a missing page still proves that the request was authorized.
+const wikiProbeSlug = "homepage"
+
+// checkWiki requests the Wiki root page to verify the token and the organization binding.
+func checkWiki(ctx context.Context, client IWikiClient, profile *config.Config, primary bool) Check {
+	name := profile.OrgName + ": wiki"
+
+	//nolint:exhaustruct // optional fields use defaults
+	_, err := client.GetPageBySlug(ctx, wikiProbeSlug, domain.WikiGetPageOpts{})
+	if err != nil {
+		if upstreamStatus(err) == http.StatusNotFound {
+			return Check{
+				Name:    name,
+				Status:  StatusPass,
+				Details: fmt.Sprintf("authorized (page %q not found)", wikiProbeSlug),
+				Hint:    "",
+			}
+		}
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    apiHint(err, domain.ServiceWiki, profile, primary),
+		}
+	}
+
+	return Check{
+		Name:    name,
+		Status:  StatusPass,
+		Details: "authorized",
+		Hint:    "",
+	}
+}
+
+// checkAttachmentDirs verifies that attachments can be saved under the configured directory rules.
+func checkAttachmentDirs(cfg *config.Config) []Check {
+	if len(cfg.AttachAllowedDirs) == 0 {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return []Check{{
+				Name:    "attachments",
+				Status:  StatusFail,
+				Details: fmt.Sprintf("resolve home directory: %v", err),
+				Hint:    "Set HOME or list the directories for saved attachments in YANDEX_MCP_ATTACH_DIR",
+			}}
+		}
+		details := fmt.Sprintf("saving under %s, except the directory itself and hidden top-level directories", homeDir)
+		return []Check{{
+			Name:    "attachments",
+			Status:  StatusPass,
+			Details: details,
+			Hint:    "",
+		}}
+	}
+
+	checks := make([]Check, 0, len(cfg.AttachAllowedDirs))
+	for _, dir := range cfg.AttachAllowedDirs {
+		checks = append(checks, checkAttachmentDir(dir))
+	}
+	return checks
+}
+
+// checkAttachmentDir verifies that an allowed attachment directory exists and is writable.
+func checkAttachmentDir(dir string) Check {
+	name := "attachments " + dir
+
+	info, err := os.Stat(dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return Check{
+			Name:    name,
+			Status:  StatusWarn,
+			Details: "directory does not exist",
+			Hint:    "Create the directory or remove it from YANDEX_MCP_ATTACH_DIR",
+		}
+	case err != nil:
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: err.Error(),
+			Hint:    "Make the directory accessible to the server user",
+		}
+	case !info.IsDir():
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: "not a directory",
+			Hint:    "YANDEX_MCP_ATTACH_DIR must list directories, not files",
+		}
+	}
+
+	probe, err := os.CreateTemp(dir, ".yandex-mcp-doctor-*")
+	if err != nil {
+		return Check{
+			Name:    name,
+			Status:  StatusFail,
+			Details: "directory is not writable",
+			Hint:    "Grant the server user write permission or choose another directory in YANDEX_MCP_ATTACH_DIR",
+		}
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return Check{
+		Name:    name,
+		Status:  StatusPass,
+		Details: "writable",
+		Hint:    "",
+	}
+}
+
+// skippedCheck marks a check that cannot run because an earlier one failed.
+func skippedCheck(name string) Check {
+	return Check{
+		Name:    name,
+		Status:  StatusSkip,
+		Details: "no token",
+		Hint:    "",
+	}
+}
+
+// upstreamStatus returns the HTTP status of an upstream API error, or zero for other errors.
+func upstreamStatus(err error) int {
+	var upstreamErr domain.UpstreamError
+	if errors.As(err, &upstreamErr) {
+		return upstreamErr.HTTPStatus
+	}
+	return 0
+}
+
+// configHint suggests a fix for a configuration loading error.
+func configHint(err error) string {
+	if strings.Contains(err.Error(), "YANDEX_CLOUD_ORG_ID") {
+		return "Set YANDEX_CLOUD_ORG_ID to the organization ID shown in Tracker or Wiki administration " +
+			"(for Yandex Cloud organizations: yc organization-manager organization list)"
+	}
+	return "Fix the environment variable named in the error; see README for accepted values"
+}
+
+// tokenHint suggests a fix for a token acquisition failure based on the collected failure reasons.
+func tokenHint(reasons string, profile *config.Config, primary bool) string {
+	ycPathEnv := config.SettingEnvKey(profile.OrgName, primary, "YC_PATH")
+	switch {
+	case strings.Contains(reasons, "build HTTP client"):
+		return fmt.Sprintf("Check the certificate files in %s, %s and %s",
+			config.SettingEnvKey(profile.OrgName, primary, "HTTP_CA_FILE"),
+			config.SettingEnvKey(profile.OrgName, primary, "HTTP_CLIENT_CERT_FILE"),
+			config.SettingEnvKey(profile.OrgName, primary, "HTTP_CLIENT_KEY_FILE"))
+	case strings.Contains(reasons, "executable file not found"),
+		strings.Contains(reasons, "no such file or directory") && strings.Contains(reasons, "yc"):
+		return fmt.Sprintf("Install the Yandex Cloud CLI (yc) and run yc init, or set %s to its absolute path",
+			ycPathEnv)
+	case strings.Contains(reasons, "did not finish within"):
+		return fmt.Sprintf("yc may be waiting for an interactive login: run yc iam create-token once manually "+
+			"or increase %s", config.SettingEnvKey(profile.OrgName, primary, "YC_TIMEOUT"))
+	case strings.Contains(reasons, "no usable auth source"):
+		return fmt.Sprintf("Configure a credential source for %s=%s: an OAuth token, a service-account key "+
+			"or a logged-in yc CLI", config.SettingEnvKey(profile.OrgName, primary, "AUTH_METHOD"), profile.AuthMethod)
+	default:
+		return fmt.Sprintf("Check the credentials used by %s=%s",
+			config.SettingEnvKey(profile.OrgName, primary, "AUTH_METHOD"), profile.AuthMethod)
+	}
+}
+
+// apiHint suggests a fix for a failed Tracker or Wiki request.
+func apiHint(err error, service domain.Service, profile *config.Config, primary bool) string {
+	baseURLEnv := config.SettingEnvKey(profile.OrgName, primary, "TRACKER_BASE_URL")
+	if service == domain.ServiceWiki {
+		baseURLEnv = config.SettingEnvKey(profile.OrgName, primary, "WIKI_BASE_URL")
+	}
+
+	switch upstreamStatus(err) {
+	case 0:
+		if profile.HTTPProxyURL != "" {
+			return fmt.Sprintf("Check network access to the API through the proxy %s and %s",
+				config.SettingEnvKey(profile.OrgName, primary, "HTTP_PROXY"), baseURLEnv)
+		}
+		return fmt.Sprintf("Check network access to the API and %s", baseURLEnv)
+	case http.StatusUnauthorized:
+		return "The token was rejected: renew the OAuth token or re-run yc init; " +
+			"OAuth tokens need the " + string(service) + " scope"
+	case http.StatusForbidden:
+		hint := fmt.Sprintf("Check %s (%s) and %s (%s): a wrong organization ID or type is rejected with 403",
+			config.SettingEnvKey(profile.OrgName, primary, "CLOUD_ORG_ID"), profile.CloudOrgID,
+			config.SettingEnvKey(profile.OrgName, primary, "ORG_TYPE"), profile.OrgType)
+		if service == domain.ServiceWiki {
+			hint += "; also make sure Wiki is enabled for the organization and the user has access to it"
+		}
+		return hint
+	case http.StatusNotFound:
+		return "Check " + baseURLEnv
+	default:
+		return ""
+	}
+}
+
+// profileSection describes the effective settings of an organization profile without secrets.
+func profileSection(profile *config.Config, primary bool) Section {
+	title := "org " + profile.OrgName
+	if primary {
+		title += " (primary)"
+	} else if profile.InheritsAuth {
+		title += " (shares primary auth)"
+	}
+
+	chain := make([]string, 0, len(profile.AuthChain))
+	for _, source := range profile.AuthChain {
+		chain = append(chain, string(source))
+	}
+
+	settings := []Setting{
+		{Name: "org id", Value: profile.CloudOrgID},
+		{Name: "org type", Value: string(profile.OrgType)},
+		{Name: "tracker base url", Value: profile.TrackerBaseURL},
+		{Name: "wiki base url", Value: profile.WikiBaseURL},
+		{Name: "http timeout", Value: profile.HTTPTimeout.String()},
+		{Name: "http proxy", Value: valueOrNone(profile.RedactedHTTPProxyURL())},
+		{Name: "ca file", Value: valueOrNone(profile.HTTPCAFile)},
+		{Name: "client certificate", Value: valueOrNone(profile.HTTPClientCertFile)},
+		{Name: "auth method", Value: string(profile.AuthMethod)},
+		{Name: "auth chain", Value: strings.Join(chain, ", ")},
+		{Name: "oauth token", Value: presence(profile.OAuthToken)},
+		{Name: "oauth token file", Value: valueOrNone(profile.OAuthTokenFile)},
+		{Name: "service account key file", Value: valueOrNone(profile.ServiceAccountKeyFile)},
+	}
+
+	if slices.Contains(profile.AuthChain, config.AuthSourceYC) {
+		ycProfile := profile.YCProfile
+		if ycProfile == "" {
+			ycProfile = "(active)"
+		}
+		settings = append(settings,
+			Setting{Name: "yc path", Value: profile.YCPath},
+			Setting{Name: "yc profile", Value: ycProfile},
+			Setting{Name: "yc timeout", Value: profile.YCTimeout.String()},
+		)
+
+		tokenCache := "disabled"
+		if profile.TokenCacheEnabled {
+			tokenCache = "enabled"
+			if profile.TokenCacheDir != "" {
+				tokenCache += " (" + profile.TokenCacheDir + ")"
+			}
+		}
+		settings = append(settings, Setting{Name: "token cache", Value: tokenCache})
+	}
+
+	return Section{Title: title, Settings: settings}
+}
+
+// attachmentSection describes the attachment settings.
+func attachmentSection(cfg *config.Config) Section {
+	dirs := "home directory"
+	if len(cfg.AttachAllowedDirs) > 0 {
+		dirs = strings.Join(cfg.AttachAllowedDirs, ", ")
+	}
+
+	return Section{
+		Title: "attachments",
+		Settings: []Setting{
+			{Name: "allowed dirs", Value: dirs},
+			{Name: "allowed extensions", Value: strings.Join(cfg.AttachAllowedExtensions, ", ")},
+			{Name: "view extensions", Value: strings.Join(cfg.AttachViewExtensions, ", ")},
+			{Name: "inline max bytes", Value: strconv.FormatInt(cfg.AttachInlineMaxBytes, 10)},
+		},
+	}
+}
+
+// presence reports whether a secret is set without revealing it.
+func presence(secret string) string {
+	if secret == "" {
+		return "not set"
+	}
+	return "set"
+}
+
+// valueOrNone returns the value or a placeholder for empty values.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+// Package doctor verifies the server setup end to end and reports problems with remediation hints.
+package doctor
+
+import (
+	"context"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -source=interfaces.go -destination=mock_interfaces.go -package=doctor
+
+// ITokenProvider acquires API tokens and explains how credentials were resolved.
+type ITokenProvider interface {
+	Token(ctx context.Context, forceRefresh bool) (string, error)
+	AuthDiagnostics() domain.AuthDiagnostics
+}
+
+// ITrackerClient is the Tracker API subset used to verify access.
+type ITrackerClient interface {
+	GetCurrentUser(ctx context.Context) (*domain.TrackerUserDetail, error)
+}
+
+// IWikiClient is the Wiki API subset used to verify access.
+type IWikiClient interface {
+	GetPageBySlug(ctx context.Context, slug string, opts domain.WikiGetPageOpts) (*domain.WikiPage, error)
+}
@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=mock_interfaces.go -package=doctor
+//
+
+// Package doctor is a generated GoMock package.
+package doctor
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/n-r-w/yandex-mcp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenProvider is a mock of ITokenProvider interface.
+type MockITokenProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockITokenProviderMockRecorder is the mock recorder for MockITokenProvider.
+type MockITokenProviderMockRecorder struct {
+	mock *MockITokenProvider
+}
+
+// NewMockITokenProvider creates a new mock instance.
+func NewMockITokenProvider(ctrl *gomock.Controller) *MockITokenProvider {
+	mock := &MockITokenProvider{ctrl: ctrl}
+	mock.recorder = &MockITokenProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenProvider) EXPECT() *MockITokenProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthDiagnostics mocks base method.
+func (m *MockITokenProvider) AuthDiagnostics() domain.AuthDiagnostics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthDiagnostics")
+	ret0, _ := ret[0].(domain.AuthDiagnostics)
+	return ret0
+}
+
+// AuthDiagnostics indicates an expected call of AuthDiagnostics.
+func (mr *MockITokenProviderMockRecorder) AuthDiagnostics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthDiagnostics", reflect.TypeOf((*MockITokenProvider)(nil).AuthDiagnostics))
+}
+
+// Token mocks base method.
+func (m *MockITokenProvider) Token(ctx context.Context, forceRefresh bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token", ctx, forceRefresh)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockITokenProviderMockRecorder) Token(ctx, forceRefresh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockITokenProvider)(nil).Token), ctx, forceRefresh)
+}
+
+// MockITrackerClient is a mock of ITrackerClient interface.
+type MockITrackerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockITrackerClientMockRecorder
+	isgomock struct{}
+}
+
+// MockITrackerClientMockRecorder is the mock recorder for MockITrackerClient.
+type MockITrackerClientMockRecorder struct {
+	mock *MockITrackerClient
+}
+
+// NewMockITrackerClient creates a new mock instance.
+func NewMockITrackerClient(ctrl *gomock.Controller) *MockITrackerClient {
+	mock := &MockITrackerClient{ctrl: ctrl}
+	mock.recorder = &MockITrackerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITrackerClient) EXPECT() *MockITrackerClientMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockITrackerClient) GetCurrentUser(ctx context.Context) (*domain.TrackerUserDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx)
+	ret0, _ := ret[0].(*domain.TrackerUserDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockITrackerClientMockRecorder) GetCurrentUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockITrackerClient)(nil).GetCurrentUser), ctx)
+}
+
+// MockIWikiClient is a mock of IWikiClient interface.
+type MockIWikiClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWikiClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIWikiClientMockRecorder is the mock recorder for MockIWikiClient.
+type MockIWikiClientMockRecorder struct {
+	mock *MockIWikiClient
+}
+
+// NewMockIWikiClient creates a new mock instance.
+func NewMockIWikiClient(ctrl *gomock.Controller) *MockIWikiClient {
+	mock := &MockIWikiClient{ctrl: ctrl}
+	mock.recorder = &MockIWikiClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWikiClient) EXPECT() *MockIWikiClientMockRecorder {
+	return m.recorder
+}
+
+// GetPageBySlug mocks base method.
+func (m *MockIWikiClient) GetPageBySlug(ctx context.Context, slug string, opts domain.WikiGetPageOpts) (*domain.WikiPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageBySlug", ctx, slug, opts)
+	ret0, _ := ret[0].(*domain.WikiPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageBySlug indicates an expected call of GetPageBySlug.
+func (mr *MockIWikiClientMockRecorder) GetPageBySlug(ctx, slug, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageBySlug", reflect.TypeOf((*MockIWikiClient)(nil).GetPageBySlug), ctx, slug, opts)
+}
@@ -0,0 +1,96 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+// Check outcomes.
+const (
+	StatusPass Status = "PASS"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Setting is a single effective configuration value shown in the report.
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// Section groups effective settings under a title.
+type Section struct {
+	Title    string
+	Settings []Setting
+}
+
+// Check is the result of a single verification step.
+type Check struct {
+	Name    string
+	Status  Status
+	Details string
+	// Hint suggests how to fix a failed or suspicious check.
+	Hint string
+}
+
+// Report collects effective settings and check results.
+type Report struct {
+	Sections []Section
+	Checks   []Check
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes a human-readable report.
+func (r *Report) Print(w io.Writer) error {
+	var b strings.Builder
+
+	if len(r.Sections) > 0 {
+		b.WriteString("Effective settings:\n")
+		tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		for _, section := range r.Sections {
+			_, _ = fmt.Fprintf(tw, "  %s\n", section.Title)
+			for _, setting := range section.Settings {
+				_, _ = fmt.Fprintf(tw, "    %s:\t%s\n", setting.Name, setting.Value)
+			}
+		}
+		_ = tw.Flush()
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Checks:\n")
+	counts := make(map[Status]int, len(r.Checks))
+	for _, check := range r.Checks {
+		counts[check.Status]++
+		_, _ = fmt.Fprintf(&b, "  [%s] %s", check.Status, check.Name)
+		if check.Details != "" {
+			_, _ = fmt.Fprintf(&b, ": %s", check.Details)
+		}
+		b.WriteString("\n")
+		if check.Hint != "" {
+			_, _ = fmt.Fprintf(&b, "         hint: %s\n", check.Hint)
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "\nSummary: %d passed, %d warnings, %d failed, %d skipped\n",
+		counts[StatusPass], counts[StatusWarn], counts[StatusFail], counts[StatusSkip])
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
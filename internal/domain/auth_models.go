@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// DiagnosticsToolAuth is the MCP tool name of the authentication diagnostics tool.
+// It belongs to no service, so it is selected by name only.
+const DiagnosticsToolAuth = "auth_diagnostics"
+
+// AuthSourceAttempt describes the outcome of trying a single credential source.
+type AuthSourceAttempt struct {
+	Source    string
+	Succeeded bool
+	Skipped   bool
+	Reason    string // sanitized failure or skip reason; empty on success
+}
+
+// AuthDiagnostics describes how the server resolved its API credentials.
+type AuthDiagnostics struct {
+	Method         string
+	Chain          []string
+	SelectedSource string // empty until a source succeeds
+	ResolvedAt     time.Time
+	Attempts       []AuthSourceAttempt
+}
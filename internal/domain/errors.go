@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -57,6 +58,33 @@ func (e UpstreamError) Error() string {
 	return b.String()
 }
 
+// ServiceUnavailableError reports that requests to an upstream service are suspended
+// after repeated failures, so callers fail fast instead of waiting for timeouts.
+type ServiceUnavailableError struct {
+	Service    Service
+	RetryAfter time.Duration // time until the next probe request is allowed
+}
+
+// Error implements the error interface.
+func (e ServiceUnavailableError) Error() string {
+	return string(e.Service) + ": service temporarily unavailable, retry in " +
+		e.RetryAfter.Round(time.Second).String()
+}
+
+// ResponseTooLargeError reports that an upstream response body exceeded the configured size limit.
+type ResponseTooLargeError struct {
+	Service   Service
+	Operation string
+	MaxBytes  int64
+}
+
+// Error implements the error interface.
+func (e ResponseTooLargeError) Error() string {
+	return string(e.Service) + " " + e.Operation + ": response exceeds the limit of " +
+		strconv.FormatInt(e.MaxBytes, 10) + " bytes; narrow the query " +
+		"(use a stricter filter, a smaller page size or fewer expanded fields)"
+}
+
 // NewUpstreamError creates a new UpstreamError with sanitized details.
 func NewUpstreamError(
 	service Service,
@@ -0,0 +1,195 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/cachecontrol"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+const (
+	// probeTTL is how long a readiness result is served before the next probe.
+	probeTTL = 30 * time.Second
+	// probeTimeout bounds a single readiness probe of all organization profiles.
+	probeTimeout = 10 * time.Second
+	// wikiProbeSlug is the Wiki root page; a missing page still proves that the request was authorized.
+	wikiProbeSlug = "homepage"
+)
+
+// Check statuses.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Target is an organization profile whose credentials and API access are probed.
+// A nil Tracker or Wiki client skips the service, e.g. when its tools are disabled.
+type Target struct {
+	Org           string
+	TokenProvider ITokenProvider
+	Tracker       ITrackerClient
+	Wiki          IWikiClient
+}
+
+// Check is the result of a single probe step.
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Result is the outcome of a readiness probe.
+type Result struct {
+	Ready     bool      `json:"ready"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Check   `json:"checks"`
+}
+
+// Checker probes token acquisition and Tracker and Wiki access of every organization profile.
+// The configuration is validated at startup, so a running server always has a valid one.
+// Results are cached, so frequent readiness probes do not load the APIs.
+type Checker struct {
+	targets []Target
+	nowFunc func() time.Time
+
+	mu     sync.Mutex // held during a probe, so concurrent requests share its result
+	result *Result
+}
+
+// NewChecker creates a readiness checker of the given organization profiles.
+func NewChecker(targets []Target) *Checker {
+	return &Checker{
+		targets: targets,
+		nowFunc: time.Now,
+		mu:      sync.Mutex{},
+		result:  nil,
+	}
+}
+
+// Check returns the cached readiness result, probing the profiles when it is missing or outdated.
+func (c *Checker) Check(ctx context.Context) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.result != nil && c.nowFunc().Sub(c.result.CheckedAt) < probeTTL {
+		return *c.result
+	}
+
+	// the result is shared with other requests, so it must not depend on the cancellation of this one;
+	// the probes skip the API response caches, which would otherwise hide failures for their TTL
+	ctx, cancel := context.WithTimeout(cachecontrol.NewBypassContext(context.WithoutCancel(ctx)), probeTimeout)
+	defer cancel()
+
+	result := &Result{
+		Ready:     true,
+		CheckedAt: c.nowFunc(),
+		Checks:    nil,
+	}
+	for _, target := range c.targets {
+		result.Checks = append(result.Checks, probeTarget(ctx, target)...)
+	}
+	for _, check := range result.Checks {
+		if check.Status != StatusOK {
+			result.Ready = false
+		}
+	}
+
+	c.result = result
+	return *result
+}
+
+// probeTarget acquires a token and calls Tracker and Wiki on behalf of the organization profile.
+// Failures are logged, since the details may reveal credentials sources and are not served to probes.
+func probeTarget(ctx context.Context, target Target) []Check {
+	prefix := target.Org + ": "
+
+	_, tokenErr := target.TokenProvider.Token(ctx, false)
+	checks := []Check{probeCheck(ctx, target.Org, "token", tokenErr)}
+
+	if target.Tracker != nil {
+		trackerErr := tokenErr
+		if tokenErr == nil {
+			_, trackerErr = target.Tracker.GetCurrentUser(ctx)
+			trackerErr = logged(ctx, target.Org, "tracker", trackerErr)
+		}
+		checks = append(checks, Check{Name: prefix + "tracker", Status: checkStatus(trackerErr)})
+	}
+
+	if target.Wiki != nil {
+		wikiErr := tokenErr
+		if tokenErr == nil {
+			//nolint:exhaustruct // optional fields use defaults
+			_, wikiErr = target.Wiki.GetPageBySlug(ctx, wikiProbeSlug, domain.WikiGetPageOpts{})
+			if isNotFound(wikiErr) {
+				wikiErr = nil
+			}
+			wikiErr = logged(ctx, target.Org, "wiki", wikiErr)
+		}
+		checks = append(checks, Check{Name: prefix + "wiki", Status: checkStatus(wikiErr)})
+	}
+
+	return checks
+}
+
+// probeCheck converts the error of a probe step into a check, logging the failure reason.
+func probeCheck(ctx context.Context, org, step string, err error) Check {
+	return Check{Name: org + ": " + step, Status: checkStatus(logged(ctx, org, step, err))}
+}
+
+// checkStatus returns the status of a probe step with the given error.
+func checkStatus(err error) string {
+	if err != nil {
+		return StatusFail
+	}
+	return StatusOK
+}
+
+// logged logs the reason of a failed probe step and returns the error.
+func logged(ctx context.Context, org, step string, err error) error {
+	if err != nil {
+		slog.WarnContext(ctx, "readiness probe failed",
+			slog.String("org", org),
+			slog.String("check", step),
+			slog.String("error", err.Error()),
+		)
+	}
+	return err
+}
+
+// isNotFound reports whether the error is an upstream 404 response.
+func isNotFound(err error) bool {
+	var upstreamErr domain.UpstreamError
+	return errors.As(err, &upstreamErr) && upstreamErr.HTTPStatus == http.StatusNotFound
+}
+
+// LivenessHandler reports that the process is alive and serving requests.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(StatusOK + "\n"))
+	})
+}
+
+// ReadinessHandler serves the readiness result as JSON with status 200 when ready and 503 otherwise.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := c.Check(r.Context())
+
+		status := http.StatusOK
+		if !result.Ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			slog.WarnContext(r.Context(), "failed to write readiness result", slog.String("error", err.Error()))
+		}
+	})
+}
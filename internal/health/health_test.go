@@ -0,0 +1,216 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/tracker"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testTarget holds the mocks of a probed organization profile.
+type testTarget struct {
+	tokenProvider *MockITokenProvider
+	tracker       *MockITrackerClient
+	wiki          *MockIWikiClient
+}
+
+// newTestTarget creates mocks of an organization profile.
+func newTestTarget(t *testing.T) *testTarget {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	return &testTarget{
+		tokenProvider: NewMockITokenProvider(ctrl),
+		tracker:       NewMockITrackerClient(ctrl),
+		wiki:          NewMockIWikiClient(ctrl),
+	}
+}
+
+// target returns the probed organization profile.
+func (tt *testTarget) target(org string) Target {
+	return Target{
+		Org:           org,
+		TokenProvider: tt.tokenProvider,
+		Tracker:       tt.tracker,
+		Wiki:          tt.wiki,
+	}
+}
+
+// serveReadiness requests the readiness endpoint and decodes the result.
+func serveReadiness(t *testing.T, checker *Checker) (int, Result) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequestWithContext(
+		t.Context(), http.MethodGet, "/readyz", http.NoBody))
+
+	var result Result
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	return recorder.Code, result
+}
+
+func TestChecker_Ready(t *testing.T) {
+	t.Parallel()
+
+	mocks := newTestTarget(t)
+	mocks.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("token", nil)
+	//nolint:exhaustruct // only the presence of the user matters
+	mocks.tracker.EXPECT().GetCurrentUser(gomock.Any()).Return(&domain.TrackerUserDetail{}, nil)
+	// a missing Wiki root page still proves the request was authorized
+	mocks.wiki.EXPECT().GetPageBySlug(gomock.Any(), wikiProbeSlug, gomock.Any()).
+		Return(nil, domain.UpstreamError{Service: domain.ServiceWiki, HTTPStatus: http.StatusNotFound})
+
+	status, result := serveReadiness(t, NewChecker([]Target{mocks.target("default")}))
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, result.Ready)
+	assert.Equal(t, []Check{
+		{Name: "default: token", Status: StatusOK},
+		{Name: "default: tracker", Status: StatusOK},
+		{Name: "default: wiki", Status: StatusOK},
+	}, result.Checks)
+}
+
+func TestChecker_NotReady(t *testing.T) {
+	t.Parallel()
+
+	primary := newTestTarget(t)
+	primary.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("token", nil)
+	primary.tracker.EXPECT().GetCurrentUser(gomock.Any()).
+		Return(nil, domain.UpstreamError{Service: domain.ServiceTracker, HTTPStatus: http.StatusForbidden})
+	primary.wiki.EXPECT().GetPageBySlug(gomock.Any(), wikiProbeSlug, gomock.Any()).
+		Return(&domain.WikiPage{}, nil) //nolint:exhaustruct // only the presence of the page matters
+
+	// the APIs are not called without a token
+	broken := newTestTarget(t)
+	broken.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("", errors.New("yc: not logged in"))
+
+	status, result := serveReadiness(t, NewChecker([]Target{primary.target("default"), broken.target("work")}))
+
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.False(t, result.Ready)
+	assert.Equal(t, []Check{
+		{Name: "default: token", Status: StatusOK},
+		{Name: "default: tracker", Status: StatusFail},
+		{Name: "default: wiki", Status: StatusOK},
+		{Name: "work: token", Status: StatusFail},
+		{Name: "work: tracker", Status: StatusFail},
+		{Name: "work: wiki", Status: StatusFail},
+	}, result.Checks)
+}
+
+func TestChecker_SkipsDisabledServices(t *testing.T) {
+	t.Parallel()
+
+	mocks := newTestTarget(t)
+	mocks.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("token", nil)
+	//nolint:exhaustruct // only the presence of the page matters
+	mocks.wiki.EXPECT().GetPageBySlug(gomock.Any(), wikiProbeSlug, gomock.Any()).Return(&domain.WikiPage{}, nil)
+
+	target := mocks.target("default")
+	target.Tracker = nil
+
+	result := NewChecker([]Target{target}).Check(t.Context())
+
+	assert.True(t, result.Ready)
+	assert.Equal(t, []Check{
+		{Name: "default: token", Status: StatusOK},
+		{Name: "default: wiki", Status: StatusOK},
+	}, result.Checks)
+}
+
+func TestChecker_CachesResult(t *testing.T) {
+	t.Parallel()
+
+	mocks := newTestTarget(t)
+	gomock.InOrder(
+		mocks.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("", errors.New("token expired")),
+		mocks.tokenProvider.EXPECT().Token(gomock.Any(), false).Return("token", nil),
+	)
+	//nolint:exhaustruct // only the presence of the user matters
+	mocks.tracker.EXPECT().GetCurrentUser(gomock.Any()).Return(&domain.TrackerUserDetail{}, nil)
+	//nolint:exhaustruct // only the presence of the page matters
+	mocks.wiki.EXPECT().GetPageBySlug(gomock.Any(), wikiProbeSlug, gomock.Any()).Return(&domain.WikiPage{}, nil)
+
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	checker := NewChecker([]Target{mocks.target("default")})
+	checker.nowFunc = func() time.Time { return now }
+
+	assert.False(t, checker.Check(t.Context()).Ready)
+	now = now.Add(probeTTL - time.Second)
+	assert.False(t, checker.Check(t.Context()).Ready, "the cached result is served until it expires")
+
+	now = now.Add(time.Second)
+	result := checker.Check(t.Context())
+	assert.True(t, result.Ready)
+	assert.Equal(t, now, result.CheckedAt)
+}
+
+func TestChecker_ProbesSkipResponseCache(t *testing.T) {
+	t.Parallel()
+
+	var upstreamDown atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if upstreamDown.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"login":"jdoe"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	//nolint:exhaustruct // only the settings of the cached Tracker client matter
+	cfg := &config.Config{
+		TrackerBaseURL:      server.URL,
+		CloudOrgID:          "org-1",
+		HTTPCacheEnabled:    true,
+		HTTPCacheMaxEntries: 10,
+	}
+	tokenProvider := apihelpers.NewMockITokenProvider(gomock.NewController(t))
+	tokenProvider.EXPECT().Token(gomock.Any(), false).Return("token", nil).AnyTimes()
+	tokenProvider.EXPECT().AuthScheme().Return(apihelpers.AuthSchemeBearer).AnyTimes()
+	trackerClient := tracker.NewClient(cfg, tokenProvider, nil, nil)
+
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	checker := NewChecker([]Target{{Org: "default", TokenProvider: tokenProvider, Tracker: trackerClient, Wiki: nil}})
+	checker.nowFunc = func() time.Time { return now }
+
+	// a tool call caches the current user
+	_, err := trackerClient.GetCurrentUser(t.Context())
+	require.NoError(t, err)
+	require.True(t, checker.Check(t.Context()).Ready)
+
+	upstreamDown.Store(true)
+	_, err = trackerClient.GetCurrentUser(t.Context())
+	require.NoError(t, err, "tool calls are still served from the response cache")
+
+	now = now.Add(probeTTL)
+	status, result := serveReadiness(t, checker)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, []Check{
+		{Name: "default: token", Status: StatusOK},
+		{Name: "default: tracker", Status: StatusFail},
+	}, result.Checks)
+}
+
+func TestLivenessHandler(t *testing.T) {
+	t.Parallel()
+
+	recorder := httptest.NewRecorder()
+	LivenessHandler().ServeHTTP(recorder, httptest.NewRequestWithContext(
+		t.Context(), http.MethodGet, "/healthz", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ok\n", recorder.Body.String())
+}
@@ -0,0 +1,25 @@
+// Package health reports liveness and readiness of a hosted server.
+package health
+
+import (
+	"context"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -source=interfaces.go -destination=mock_interfaces.go -package=health
+
+// ITokenProvider acquires API tokens.
+type ITokenProvider interface {
+	Token(ctx context.Context, forceRefresh bool) (string, error)
+}
+
+// ITrackerClient is the Tracker API subset used to probe access.
+type ITrackerClient interface {
+	GetCurrentUser(ctx context.Context) (*domain.TrackerUserDetail, error)
+}
+
+// IWikiClient is the Wiki API subset used to probe access.
+type IWikiClient interface {
+	GetPageBySlug(ctx context.Context, slug string, opts domain.WikiGetPageOpts) (*domain.WikiPage, error)
+}
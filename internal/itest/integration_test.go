@@ -10,16 +10,29 @@ import (
 
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
 	trackertools "github.com/n-r-w/yandex-mcp/internal/tools/tracker"
 	wikitools "github.com/n-r-w/yandex-mcp/internal/tools/wiki"
 )
 
+const testOrgName = "default"
+
 var (
 	defaultAttachExtensions = []string{"txt"}
 	defaultAttachViewExts   = []string{"txt"}
 	defaultAttachDirs       []string
 )
 
+// wikiRouter wraps a wiki adapter into a router with only the primary organization profile.
+func wikiRouter(adapter wikitools.IWikiAdapter) *helpers.OrgRouter[wikitools.IWikiAdapter] {
+	return helpers.NewOrgRouter(testOrgName, map[string]wikitools.IWikiAdapter{testOrgName: adapter})
+}
+
+// trackerRouter wraps a tracker adapter into a router with only the primary organization profile.
+func trackerRouter(adapter trackertools.ITrackerAdapter) *helpers.OrgRouter[trackertools.ITrackerAdapter] {
+	return helpers.NewOrgRouter(testOrgName, map[string]trackertools.ITrackerAdapter{testOrgName: adapter})
+}
+
 func listToolNames(t *testing.T, srv *server.Server) []string {
 	t.Helper()
 
@@ -59,9 +72,9 @@ func TestServerIntegration_ReadOnlyToolsRegistered(t *testing.T) {
 	trackerMock := trackertools.NewMockITrackerAdapter(ctrl)
 
 	registrators := []server.IToolsRegistrator{
-		wikitools.NewRegistrator(wikiMock, domain.WikiAllTools()),
+		wikitools.NewRegistrator(wikiRouter(wikiMock), domain.WikiAllTools()),
 		trackertools.NewRegistrator(
-			trackerMock,
+			trackerRouter(trackerMock),
 			domain.TrackerAllTools(),
 			defaultAttachExtensions,
 			defaultAttachViewExts,
@@ -69,7 +82,7 @@ func TestServerIntegration_ReadOnlyToolsRegistered(t *testing.T) {
 		),
 	}
 
-	srv, err := server.New("v1.0.0", registrators)
+	srv, err := server.New("v1.0.0", registrators, nil, nil)
 	require.NoError(t, err)
 
 	toolNames := listToolNames(t, srv)
@@ -96,9 +109,9 @@ func TestServerIntegration_AllowlistGating_ReducedList(t *testing.T) {
 	trackerTools := []domain.TrackerTool{domain.TrackerToolIssueGet}
 
 	registrators := []server.IToolsRegistrator{
-		wikitools.NewRegistrator(wikiMock, wikiTools),
+		wikitools.NewRegistrator(wikiRouter(wikiMock), wikiTools),
 		trackertools.NewRegistrator(
-			trackerMock,
+			trackerRouter(trackerMock),
 			trackerTools,
 			defaultAttachExtensions,
 			defaultAttachViewExts,
@@ -106,7 +119,7 @@ func TestServerIntegration_AllowlistGating_ReducedList(t *testing.T) {
 		),
 	}
 
-	srv, err := server.New("v1.0.0", registrators)
+	srv, err := server.New("v1.0.0", registrators, nil, nil)
 	require.NoError(t, err)
 
 	toolNames := listToolNames(t, srv)
@@ -128,9 +141,9 @@ func TestServerIntegration_EmptyAllowlist_NoToolsRegistered(t *testing.T) {
 	trackerMock := trackertools.NewMockITrackerAdapter(ctrl)
 
 	registrators := []server.IToolsRegistrator{
-		wikitools.NewRegistrator(wikiMock, nil),
+		wikitools.NewRegistrator(wikiRouter(wikiMock), nil),
 		trackertools.NewRegistrator(
-			trackerMock,
+			trackerRouter(trackerMock),
 			nil,
 			defaultAttachExtensions,
 			defaultAttachViewExts,
@@ -138,7 +151,7 @@ func TestServerIntegration_EmptyAllowlist_NoToolsRegistered(t *testing.T) {
 		),
 	}
 
-	srv, err := server.New("v1.0.0", registrators)
+	srv, err := server.New("v1.0.0", registrators, nil, nil)
 	require.NoError(t, err)
 
 	toolNames := listToolNames(t, srv)
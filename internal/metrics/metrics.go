@@ -0,0 +1,126 @@
+// Package metrics collects Prometheus metrics of the server and serves them over HTTP.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/adapters/apihelpers"
+	"github.com/n-r-w/yandex-mcp/internal/adapters/ytoken"
+	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the names of all metrics.
+const namespace = "yandex_mcp"
+
+// Metrics holds the server metrics in a dedicated registry.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls               *prometheus.CounterVec
+	toolCallDuration        *prometheus.HistogramVec
+	upstreamRequests        *prometheus.CounterVec
+	upstreamRequestDuration *prometheus.HistogramVec
+	tokenRefreshes          *prometheus.CounterVec
+	tokenRefreshFailures    *prometheus.CounterVec
+	cacheLookups            *prometheus.CounterVec
+}
+
+// Compile-time interface assertions.
+var (
+	_ server.IToolMetrics  = (*Metrics)(nil)
+	_ apihelpers.IMetrics  = (*Metrics)(nil)
+	_ ytoken.ITokenMetrics = (*Metrics)(nil)
+)
+
+// New creates the server metrics together with the Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: newCounterVec("tool_calls_total",
+			"Number of MCP tool calls by tool and status (ok or error).",
+			"tool", "status"),
+		toolCallDuration: newHistogramVec("tool_call_duration_seconds",
+			"Duration of MCP tool calls by tool.",
+			"tool"),
+		upstreamRequests: newCounterVec("upstream_requests_total",
+			"Number of Yandex API requests by service, operation and final HTTP status or error.",
+			"service", "operation", "status"),
+		upstreamRequestDuration: newHistogramVec("upstream_request_duration_seconds",
+			"Duration of Yandex API requests including retries by service and operation.",
+			"service", "operation"),
+		tokenRefreshes: newCounterVec("token_refreshes_total",
+			"Number of token refresh attempts by organization profile and credential source.",
+			"org", "source"),
+		tokenRefreshFailures: newCounterVec("token_refresh_failures_total",
+			"Number of failed token refresh attempts by organization profile and credential source.",
+			"org", "source"),
+		cacheLookups: newCounterVec("cache_lookups_total",
+			"Number of response cache lookups by service and result (hit, miss or revalidated).",
+			"service", "result"),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), //nolint:exhaustruct // defaults
+		m.toolCalls,
+		m.toolCallDuration,
+		m.upstreamRequests,
+		m.upstreamRequestDuration,
+		m.tokenRefreshes,
+		m.tokenRefreshFailures,
+		m.cacheLookups,
+	)
+
+	return m
+}
+
+// newCounterVec creates a counter vector in the server namespace.
+func newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	//nolint:exhaustruct // optional fields use defaults
+	return prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Name: name, Help: help}, labels)
+}
+
+// newHistogramVec creates a histogram vector with the default latency buckets in the server namespace.
+func newHistogramVec(name, help string, labels ...string) *prometheus.HistogramVec {
+	//nolint:exhaustruct // optional fields use defaults
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+		Buckets:   prometheus.DefBuckets,
+	}, labels)
+}
+
+// Handler returns an HTTP handler exposing the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}) //nolint:exhaustruct // optional fields use defaults
+}
+
+// ObserveToolCall records a completed tool call.
+func (m *Metrics) ObserveToolCall(tool, status string, duration time.Duration) {
+	m.toolCalls.WithLabelValues(tool, status).Inc()
+	m.toolCallDuration.WithLabelValues(tool).Observe(duration.Seconds())
+}
+
+// ObserveUpstreamRequest records a completed upstream request.
+func (m *Metrics) ObserveUpstreamRequest(service, operation, status string, duration time.Duration) {
+	m.upstreamRequests.WithLabelValues(service, operation, status).Inc()
+	m.upstreamRequestDuration.WithLabelValues(service, operation).Observe(duration.Seconds())
+}
+
+// ObserveTokenRefresh records a token refresh attempt; a non-nil err counts as a failure.
+func (m *Metrics) ObserveTokenRefresh(org, source string, err error) {
+	m.tokenRefreshes.WithLabelValues(org, source).Inc()
+	if err != nil {
+		m.tokenRefreshFailures.WithLabelValues(org, source).Inc()
+	}
+}
+
+// ObserveCacheLookup records a response cache lookup.
+func (m *Metrics) ObserveCacheLookup(service, result string) {
+	m.cacheLookups.WithLabelValues(service, result).Inc()
+}
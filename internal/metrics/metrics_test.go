@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_Observe(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+
+	m.ObserveToolCall("tracker_issue_get", "ok", 10*time.Millisecond)
+	m.ObserveToolCall("tracker_issue_get", "error", 20*time.Millisecond)
+	m.ObserveUpstreamRequest("tracker", "GetIssue", "200", 5*time.Millisecond)
+	m.ObserveUpstreamRequest("tracker", "GetIssue", "error", time.Millisecond)
+	m.ObserveTokenRefresh("acme", "metadata", nil)
+	m.ObserveTokenRefresh("acme", "metadata", errors.New("unavailable"))
+	m.ObserveCacheLookup("wiki", "hit")
+	m.ObserveCacheLookup("wiki", "hit")
+	m.ObserveCacheLookup("wiki", "miss")
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.toolCalls.WithLabelValues("tracker_issue_get", "error")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.toolCallDuration))
+	assert.InDelta(t, 1, testutil.ToFloat64(m.upstreamRequests.WithLabelValues("tracker", "GetIssue", "200")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.upstreamRequests.WithLabelValues("tracker", "GetIssue", "error")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.tokenRefreshes.WithLabelValues("acme", "metadata")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.tokenRefreshFailures.WithLabelValues("acme", "metadata")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(m.cacheLookups.WithLabelValues("wiki", "hit")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.cacheLookups.WithLabelValues("wiki", "miss")), 0)
+
+	problems, err := testutil.GatherAndLint(m.registry)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	m.ObserveToolCall("wiki_page_get", "ok", time.Millisecond)
+
+	server := httptest.NewServer(m.Handler())
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `yandex_mcp_tool_calls_total{status="ok",tool="wiki_page_get"} 1`)
+	assert.Contains(t, string(body), "yandex_mcp_tool_call_duration_seconds_bucket")
+	assert.Contains(t, string(body), "go_goroutines")
+}
@@ -0,0 +1,34 @@
+// Package pause provides the waiting primitives shared by retry loops of the API clients and token refreshers.
+package pause
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter randomizes d within [d/2, d) to avoid synchronized retries of concurrent callers.
+func Jitter(d time.Duration) time.Duration {
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half) //nolint:gosec // jitter does not require cryptographic randomness
+}
+
+// Sleep waits for d or until ctx is done. It reports whether the full duration elapsed.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
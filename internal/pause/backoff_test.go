@@ -0,0 +1,32 @@
+package pause
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitter(t *testing.T) {
+	t.Parallel()
+
+	for range 100 {
+		d := Jitter(time.Second)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.Less(t, d, time.Second)
+	}
+	assert.Equal(t, time.Duration(1), Jitter(1))
+}
+
+func TestSleep(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Sleep(t.Context(), time.Millisecond))
+	assert.True(t, Sleep(t.Context(), 0))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.False(t, Sleep(ctx, time.Hour))
+	assert.False(t, Sleep(ctx, 0))
+}
@@ -1,10 +1,27 @@
 //nolint:lll // systemPrompt contains long URLs that must stay on single line for readability
 package server
 
+import "time"
+
 const (
 	serverName  = "yandex-mcp"
 	serverTitle = "Yandex MCP Server"
 
+	// tracerName is the instrumentation scope of tool call spans.
+	tracerName = "github.com/n-r-w/yandex-mcp/internal/server"
+
+	// methodCallTool is the MCP method of tool calls.
+	methodCallTool = "tools/call"
+
+	// httpSessionTimeout closes HTTP sessions of clients that went away without deleting them.
+	httpSessionTimeout = 30 * time.Minute
+	// clientIDBytes is the number of token hash bytes identifying an HTTP client.
+	clientIDBytes = 8
+
+	// Tool call statuses reported to metrics.
+	toolStatusOK    = "ok"
+	toolStatusError = "error"
+
 	systemPrompt = `This MCP server provides access to various tools for interacting with Yandex services.
 YANDEX WIKI rules:
 - Any pages of the *wiki.yandex.* type must be loaded via Yandex Wiki tools. Example: https://wiki.yandex.com/homepage/xxx/ -> wiki_page_get(slug: homepage/xxx)
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+)
+
+// HTTPOptions configures the streamable HTTP handler.
+type HTTPOptions struct {
+	// ClientTokens lists the bearer tokens accepted from MCP clients.
+	ClientTokens []string
+	// CallerCredentials controls whether clients may pass their own Yandex token in the callerauth.Header header.
+	CallerCredentials config.CallerCredentialsMode
+}
+
+// HTTPHandler returns the streamable HTTP handler of the server. Every request must carry
+// one of the accepted client tokens as a bearer token; sessions are bound to the token they started with.
+func (s *Server) HTTPHandler(opts HTTPOptions) (http.Handler, error) {
+	if len(opts.ClientTokens) == 0 {
+		return nil, errors.New("no client tokens configured")
+	}
+
+	handler := mcp.NewStreamableHTTPHandler(
+		func(*http.Request) *mcp.Server { return s.mcpServer },
+		//nolint:exhaustruct // optional fields use defaults
+		&mcp.StreamableHTTPOptions{
+			Logger:         slog.Default(),
+			SessionTimeout: httpSessionTimeout,
+		},
+	)
+
+	return auth.RequireBearerToken(clientTokenVerifier(opts.ClientTokens), nil)(
+		callerCredentialsMiddleware(opts.CallerCredentials, handler)), nil
+}
+
+// callerCredentialsMiddleware applies the caller credentials mode to the callerauth.Header header.
+// When the mode is off the header is dropped, so tool calls use the server's credentials.
+func callerCredentialsMiddleware(mode config.CallerCredentialsMode, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get(callerauth.Header)
+		switch {
+		case mode == config.CallerCredentialsOff:
+			r.Header.Del(callerauth.Header)
+		case value == "":
+			if mode == config.CallerCredentialsRequired {
+				http.Error(w, callerauth.Header+" header is required", http.StatusUnauthorized)
+				return
+			}
+		default:
+			if _, err := callerauth.ParseHeader(value); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientTokenVerifier accepts the configured client tokens. The client ID reported to the SDK
+// is derived from the token, so a session cannot be continued with another client's token.
+func clientTokenVerifier(clientTokens []string) auth.TokenVerifier {
+	return func(_ context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+		// every token is compared to avoid leaking the position of a match through timing
+		matched := 0
+		for _, accepted := range clientTokens {
+			matched |= subtle.ConstantTimeCompare([]byte(token), []byte(accepted))
+		}
+		if matched == 0 {
+			return nil, fmt.Errorf("%w: unknown client token", auth.ErrInvalidToken)
+		}
+
+		return &auth.TokenInfo{
+			Scopes: nil,
+			// static tokens do not expire, but the SDK requires an expiration
+			Expiration: time.Now().Add(time.Hour),
+			UserID:     clientID(token),
+			Extra:      nil,
+		}, nil
+	}
+}
+
+// clientID returns a stable identifier of a client token that does not reveal the token.
+func clientID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:clientIDBytes])
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// bearerTransport adds a bearer token and optional caller credentials to every request.
+type bearerTransport struct {
+	token             string
+	callerCredentials string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	if t.callerCredentials != "" {
+		req.Header.Set(callerauth.Header, t.callerCredentials)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newHTTPTestServer serves the echo tool over streamable HTTP with the given options.
+func newHTTPTestServer(t *testing.T, opts HTTPOptions, registrators ...IToolsRegistrator) *httptest.Server {
+	t.Helper()
+
+	if len(registrators) == 0 {
+		registrators = []IToolsRegistrator{newEchoRegistrator(gomock.NewController(t))}
+	}
+	srv, err := New("v1.0.0", registrators, nil, nil)
+	require.NoError(t, err)
+
+	handler, err := srv.HTTPHandler(opts)
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestServer_HTTPHandler_ServesAuthorizedClients(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newHTTPTestServer(t, HTTPOptions{
+		ClientTokens:      []string{"token-1", "token-2"},
+		CallerCredentials: config.CallerCredentialsOff,
+	})
+
+	session, err := connectHTTPTestSession(t, httpServer, bearerTransport{token: "token-2", callerCredentials: ""})
+	require.NoError(t, err)
+
+	callEchoTools(t, session)
+}
+
+// connectHTTPTestSession connects a streamable HTTP client session to the server through the transport.
+func connectHTTPTestSession(
+	t *testing.T,
+	httpServer *httptest.Server,
+	transport http.RoundTripper,
+) (*mcp.ClientSession, error) {
+	t.Helper()
+
+	client := mcp.NewClient(
+		&mcp.Implementation{ //nolint:exhaustruct // optional fields use defaults
+			Name:    "test-client",
+			Version: "v1.0.0",
+		},
+		nil,
+	)
+	session, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{ //nolint:exhaustruct // defaults
+		Endpoint:   httpServer.URL,
+		HTTPClient: &http.Client{Transport: transport}, //nolint:exhaustruct // defaults
+		MaxRetries: -1,
+	}, nil)
+	if err == nil {
+		t.Cleanup(func() { _ = session.Close() })
+	}
+	return session, err
+}
+
+// callerOutput reports the caller credentials seen by a tool.
+type callerOutput struct {
+	CallerID string `json:"caller_id"`
+}
+
+// newCallerRegistrator registers a tool reporting the ID of the caller credentials, if any.
+func newCallerRegistrator(ctrl *gomock.Controller) IToolsRegistrator {
+	registrator := NewMockIToolsRegistrator(ctrl)
+	registrator.EXPECT().Register(gomock.Any()).DoAndReturn(func(srv *mcp.Server) error {
+		mcp.AddTool(srv, &mcp.Tool{ //nolint:exhaustruct // optional fields use defaults
+			Name:        "caller",
+			Description: "Reports the caller credentials",
+		}, MakeHandler(func(ctx context.Context, _ struct{}) (*callerOutput, error) {
+			output := &callerOutput{CallerID: ""}
+			if credentials, ok := callerauth.FromContext(ctx); ok {
+				output.CallerID = credentials.ID()
+			}
+			return output, nil
+		}))
+		return nil
+	})
+	return registrator
+}
+
+func TestServer_HTTPHandler_CallerCredentials(t *testing.T) {
+	t.Parallel()
+
+	callerID := callerauth.Credentials{Scheme: callerauth.SchemeOAuth, Token: "y0_caller"}.ID()
+
+	testCases := []struct {
+		name              string
+		mode              config.CallerCredentialsMode
+		callerCredentials string
+		wantCallerID      string
+		wantRejected      bool
+	}{
+		{name: "off ignores the header", mode: config.CallerCredentialsOff,
+			callerCredentials: "OAuth y0_caller", wantCallerID: "", wantRejected: false},
+		{name: "optional with header", mode: config.CallerCredentialsOptional,
+			callerCredentials: "oauth y0_caller", wantCallerID: callerID, wantRejected: false},
+		{name: "optional without header", mode: config.CallerCredentialsOptional,
+			callerCredentials: "", wantCallerID: "", wantRejected: false},
+		{name: "optional with malformed header", mode: config.CallerCredentialsOptional,
+			callerCredentials: "Basic y0_caller", wantCallerID: "", wantRejected: true},
+		{name: "required with header", mode: config.CallerCredentialsRequired,
+			callerCredentials: "OAuth y0_caller", wantCallerID: callerID, wantRejected: false},
+		{name: "required without header", mode: config.CallerCredentialsRequired,
+			callerCredentials: "", wantCallerID: "", wantRejected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpServer := newHTTPTestServer(t,
+				HTTPOptions{ClientTokens: []string{"token-1"}, CallerCredentials: testCase.mode},
+				newCallerRegistrator(gomock.NewController(t)),
+			)
+
+			session, err := connectHTTPTestSession(t, httpServer,
+				bearerTransport{token: "token-1", callerCredentials: testCase.callerCredentials})
+			if testCase.wantRejected {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			//nolint:exhaustruct // optional fields use defaults
+			result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "caller"})
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			output, ok := result.StructuredContent.(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, testCase.wantCallerID, output["caller_id"])
+		})
+	}
+}
+
+func TestServer_HTTPHandler_RejectsUnknownClients(t *testing.T) {
+	t.Parallel()
+
+	httpServer := newHTTPTestServer(t, HTTPOptions{
+		ClientTokens:      []string{"token-1"},
+		CallerCredentials: config.CallerCredentialsOff,
+	})
+
+	testCases := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "missing token", authorization: ""},
+		{name: "unknown token", authorization: "Bearer token-2"},
+		{name: "token prefix", authorization: "Bearer token"},
+		{name: "other scheme", authorization: "Basic token-1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, httpServer.URL,
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			if testCase.authorization != "" {
+				req.Header.Set("Authorization", testCase.authorization)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+}
+
+func TestServer_HTTPHandler_RequiresClientTokens(t *testing.T) {
+	t.Parallel()
+
+	srv, err := New("v1.0.0", nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = srv.HTTPHandler(HTTPOptions{ClientTokens: nil, CallerCredentials: config.CallerCredentialsOff})
+	require.Error(t, err)
+}
+
+func TestClientID(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, clientID("token-1"), 2*clientIDBytes)
+	assert.Equal(t, clientID("token-1"), clientID("token-1"))
+	assert.NotEqual(t, clientID("token-1"), clientID("token-2"))
+	assert.NotContains(t, clientID("token-1"), "token")
+}
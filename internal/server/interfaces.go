@@ -3,9 +3,26 @@ package server
 
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -source=interfaces.go -destination=mock_interfaces.go -package=server
 
-import "github.com/modelcontextprotocol/go-sdk/mcp"
+import (
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/audit"
+)
 
 // IToolsRegistrator abstracts tool registration for dependency injection.
 type IToolsRegistrator interface {
 	Register(srv *mcp.Server) error
 }
+
+// IToolMetrics records tool call metrics.
+type IToolMetrics interface {
+	// ObserveToolCall records a completed tool call with status "ok" or "error".
+	ObserveToolCall(tool, status string, duration time.Duration)
+}
+
+// IAuditSink persists audit records of tool calls.
+type IAuditSink interface {
+	// Write stores a completed record.
+	Write(record *audit.Record) error
+}
@@ -11,8 +11,10 @@ package server
 
 import (
 	reflect "reflect"
+	time "time"
 
 	mcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	audit "github.com/n-r-w/yandex-mcp/internal/audit"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -53,3 +55,77 @@ func (mr *MockIToolsRegistratorMockRecorder) Register(srv any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIToolsRegistrator)(nil).Register), srv)
 }
+
+// MockIToolMetrics is a mock of IToolMetrics interface.
+type MockIToolMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockIToolMetricsMockRecorder
+	isgomock struct{}
+}
+
+// MockIToolMetricsMockRecorder is the mock recorder for MockIToolMetrics.
+type MockIToolMetricsMockRecorder struct {
+	mock *MockIToolMetrics
+}
+
+// NewMockIToolMetrics creates a new mock instance.
+func NewMockIToolMetrics(ctrl *gomock.Controller) *MockIToolMetrics {
+	mock := &MockIToolMetrics{ctrl: ctrl}
+	mock.recorder = &MockIToolMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIToolMetrics) EXPECT() *MockIToolMetricsMockRecorder {
+	return m.recorder
+}
+
+// ObserveToolCall mocks base method.
+func (m *MockIToolMetrics) ObserveToolCall(tool, status string, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveToolCall", tool, status, duration)
+}
+
+// ObserveToolCall indicates an expected call of ObserveToolCall.
+func (mr *MockIToolMetricsMockRecorder) ObserveToolCall(tool, status, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveToolCall", reflect.TypeOf((*MockIToolMetrics)(nil).ObserveToolCall), tool, status, duration)
+}
+
+// MockIAuditSink is a mock of IAuditSink interface.
+type MockIAuditSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditSinkMockRecorder
+	isgomock struct{}
+}
+
+// MockIAuditSinkMockRecorder is the mock recorder for MockIAuditSink.
+type MockIAuditSinkMockRecorder struct {
+	mock *MockIAuditSink
+}
+
+// NewMockIAuditSink creates a new mock instance.
+func NewMockIAuditSink(ctrl *gomock.Controller) *MockIAuditSink {
+	mock := &MockIAuditSink{ctrl: ctrl}
+	mock.recorder = &MockIAuditSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditSink) EXPECT() *MockIAuditSinkMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockIAuditSink) Write(record *audit.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockIAuditSinkMockRecorder) Write(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockIAuditSink)(nil).Write), record)
+}
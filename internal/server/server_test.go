@@ -2,13 +2,21 @@ package server
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/audit"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 )
 
@@ -78,7 +86,7 @@ func TestServer_ToolsRegistered(t *testing.T) {
 		newTrackerStubRegistrator(ctrl),
 	}
 
-	srv, err := New("v1.0.0", registrators)
+	srv, err := New("v1.0.0", registrators, nil, nil)
 	require.NoError(t, err)
 
 	ctx := t.Context()
@@ -127,7 +135,7 @@ func TestServerCreation(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
-	srv, err := New("v1.0.0", []IToolsRegistrator{newWikiStubRegistrator(ctrl)})
+	srv, err := New("v1.0.0", []IToolsRegistrator{newWikiStubRegistrator(ctrl)}, nil, nil)
 	require.NoError(t, err)
 	assert.NotNil(t, srv)
 }
@@ -135,7 +143,7 @@ func TestServerCreation(t *testing.T) {
 func TestServerCreation_EmptyRegistrators(t *testing.T) {
 	t.Parallel()
 
-	srv, err := New("v1.0.0", nil)
+	srv, err := New("v1.0.0", nil, nil, nil)
 	require.NoError(t, err)
 	assert.NotNil(t, srv)
 }
@@ -143,7 +151,7 @@ func TestServerCreation_EmptyRegistrators(t *testing.T) {
 func TestServerCreation_NoRegistrators(t *testing.T) {
 	t.Parallel()
 
-	srv, err := New("v1.0.0", []IToolsRegistrator{})
+	srv, err := New("v1.0.0", []IToolsRegistrator{}, nil, nil)
 	require.NoError(t, err)
 	assert.NotNil(t, srv)
 }
@@ -155,7 +163,166 @@ func TestServer_RegistrationError(t *testing.T) {
 	mockReg := NewMockIToolsRegistrator(ctrl)
 	mockReg.EXPECT().Register(gomock.Any()).Return(assert.AnError)
 
-	_, err := New("v1.0.0", []IToolsRegistrator{mockReg})
+	_, err := New("v1.0.0", []IToolsRegistrator{mockReg}, nil, nil)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, assert.AnError)
 }
+
+//nolint:paralleltest // replaces the global tracer provider and propagator
+func TestMakeHandler_RecordsToolCallSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	handler := MakeHandler(func(_ context.Context, input string) (*string, error) {
+		if input == "fail" {
+			return nil, errors.New("tool failed")
+		}
+		return &input, nil
+	})
+
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	//nolint:exhaustruct // optional fields use defaults
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+		Name: "wiki_page_get",
+		Meta: mcp.Meta{"traceparent": "00-" + parentTraceID + "-00f067aa0ba902b7-01"},
+	}}
+
+	_, _, err := handler(t.Context(), req, "ok")
+	require.NoError(t, err)
+	_, _, err = handler(t.Context(), req, "fail")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "tools/call wiki_page_get", spans[0].Name())
+	assert.Equal(t, parentTraceID, spans[0].SpanContext().TraceID().String())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("gen_ai.tool.name", "wiki_page_get"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "tool failed", spans[1].Status().Description)
+}
+
+// echoInput is the input of the echo tool used to test tool call middlewares.
+type echoInput struct {
+	Text string `json:"text"`
+}
+
+// newEchoRegistrator registers an "echo" tool that fails when the text is "fail".
+func newEchoRegistrator(ctrl *gomock.Controller) IToolsRegistrator {
+	registrator := NewMockIToolsRegistrator(ctrl)
+	registrator.EXPECT().Register(gomock.Any()).DoAndReturn(func(srv *mcp.Server) error {
+		mcp.AddTool(srv, &mcp.Tool{ //nolint:exhaustruct // optional fields use defaults
+			Name:        "echo",
+			Description: "Echoes the input",
+		}, MakeHandler(func(_ context.Context, input echoInput) (*echoInput, error) {
+			if input.Text == "fail" {
+				return nil, errors.New("tool failed")
+			}
+			return &input, nil
+		}))
+		return nil
+	})
+	return registrator
+}
+
+// connectTestSession connects an in-memory client session to the server.
+func connectTestSession(t *testing.T, srv *Server) *mcp.ClientSession {
+	t.Helper()
+
+	client := mcp.NewClient(
+		&mcp.Implementation{ //nolint:exhaustruct // optional fields use defaults
+			Name:    "test-client",
+			Version: "v1.0.0",
+		},
+		nil,
+	)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := srv.Connect(t.Context(), serverTransport)
+	require.NoError(t, err)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+// callEchoTools calls the echo tool successfully, with a tool error and under an unknown name.
+func callEchoTools(t *testing.T, session *mcp.ClientSession) {
+	t.Helper()
+
+	//nolint:exhaustruct // optional fields use defaults
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]any{"text": "hi"},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	//nolint:exhaustruct // optional fields use defaults
+	result, err = session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]any{"text": "fail"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	//nolint:exhaustruct // optional fields use defaults
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "missing"})
+	require.Error(t, err)
+}
+
+func TestServer_RecordsToolCallMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	metrics := NewMockIToolMetrics(ctrl)
+	// unknown tools are rejected before reaching a handler and are not recorded
+	gomock.InOrder(
+		metrics.EXPECT().ObserveToolCall("echo", "ok", gomock.Any()),
+		metrics.EXPECT().ObserveToolCall("echo", "error", gomock.Any()),
+	)
+
+	srv, err := New("v1.0.0", []IToolsRegistrator{newEchoRegistrator(ctrl)}, metrics, nil)
+	require.NoError(t, err)
+
+	callEchoTools(t, connectTestSession(t, srv))
+}
+
+func TestServer_WritesAuditRecords(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	sink := NewMockIAuditSink(ctrl)
+	var records []*audit.Record
+	sink.EXPECT().Write(gomock.Any()).DoAndReturn(func(record *audit.Record) error {
+		records = append(records, record)
+		return nil
+	}).Times(3)
+
+	srv, err := New("v1.0.0", []IToolsRegistrator{newEchoRegistrator(ctrl)}, nil, sink)
+	require.NoError(t, err)
+
+	callEchoTools(t, connectTestSession(t, srv))
+
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "echo", records[0].Tool)
+	assert.Equal(t, map[string]any{"text": "hi"}, records[0].Arguments)
+	assert.Equal(t, len(`{"text":"hi"}`), records[0].ResultBytes)
+	assert.Empty(t, records[0].ErrorClass)
+
+	assert.Equal(t, audit.ErrorClassTool, records[1].ErrorClass)
+	assert.Zero(t, records[1].ResultBytes)
+
+	assert.Equal(t, "missing", records[2].Tool)
+	assert.Equal(t, audit.ErrorClassInvalidRequest, records[2].ErrorClass)
+}
@@ -3,8 +3,17 @@ package server
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/audit"
+	"github.com/n-r-w/yandex-mcp/internal/callerauth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Server encapsulates an MCP server instance.
@@ -13,7 +22,13 @@ type Server struct {
 }
 
 // New initializes an MCP server with the given registrators.
-func New(serverVersion string, registrators []IToolsRegistrator) (*Server, error) {
+// Tool calls are recorded in metrics and written to auditSink unless they are nil.
+func New(
+	serverVersion string,
+	registrators []IToolsRegistrator,
+	metrics IToolMetrics,
+	auditSink IAuditSink,
+) (*Server, error) {
 	mcpServer := mcp.NewServer(
 		&mcp.Implementation{ //nolint:exhaustruct // optional fields use defaults
 			Name:    serverName,
@@ -26,6 +41,13 @@ func New(serverVersion string, registrators []IToolsRegistrator) (*Server, error
 		},
 	)
 
+	if metrics != nil {
+		mcpServer.AddReceivingMiddleware(toolMetricsMiddleware(metrics))
+	}
+	if auditSink != nil {
+		mcpServer.AddReceivingMiddleware(toolAuditMiddleware(auditSink))
+	}
+
 	for _, r := range registrators {
 		if err := r.Register(mcpServer); err != nil {
 			return nil, fmt.Errorf("register tools: %w", err)
@@ -46,11 +68,108 @@ func (s *Server) Connect(ctx context.Context, transport mcp.Transport) (*mcp.Ser
 }
 
 // MakeHandler adapts a tool function to the mcp.AddTool signature.
+// Each call runs in a span named after the tool, so upstream request spans are grouped by tool call.
+// For audited calls, the output size and the error class are added to the audit record.
+// Yandex credentials passed by an HTTP client in the callerauth.Header header are added to the context,
+// so upstream requests of the call run with the caller's permissions.
 func MakeHandler[In, Out any](
 	fn func(context.Context, In) (*Out, error),
 ) func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, *Out, error) {
-	return func(ctx context.Context, _ *mcp.CallToolRequest, input In) (*mcp.CallToolResult, *Out, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, *Out, error) {
+		var toolName string
+		if req != nil && req.Params != nil {
+			toolName = req.Params.Name
+			// MCP clients may propagate their trace context in the request _meta
+			ctx = otel.GetTextMapPropagator().Extract(ctx, metaCarrier(req.Params.Meta))
+		}
+
+		if req != nil && req.Extra != nil {
+			if value := req.Extra.Header.Get(callerauth.Header); value != "" {
+				credentials, err := callerauth.ParseHeader(value)
+				if err != nil {
+					return nil, nil, err
+				}
+				ctx = callerauth.NewContext(ctx, credentials)
+			}
+		}
+
+		ctx, span := otel.Tracer(tracerName).Start(ctx, "tools/call "+toolName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.McpMethodNameToolsCall, semconv.GenAIToolName(toolName)),
+		)
+		defer span.End()
+
 		output, err := fn(ctx, input)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		audit.FromContext(ctx).SetOutcome(ctx, output, err)
+
 		return nil, output, err
 	}
 }
+
+// toolMetricsMiddleware records the outcome and latency of tool calls.
+// Calls rejected before reaching the tool, such as unknown tools or invalid arguments, are not recorded,
+// which keeps the tool label bounded by the registered tools.
+func toolMetricsMiddleware(metrics IToolMetrics) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != methodCallTool || !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			status := toolStatusOK
+			if callResult, isCallResult := result.(*mcp.CallToolResult); isCallResult && callResult.IsError {
+				status = toolStatusError
+			}
+			metrics.ObserveToolCall(callReq.Params.Name, status, time.Since(start))
+
+			return result, nil
+		}
+	}
+}
+
+// toolAuditMiddleware writes an audit record of every tool call, including calls rejected before reaching the tool.
+// A failure to write the record is logged and does not fail the call.
+func toolAuditMiddleware(sink IAuditSink) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != methodCallTool || !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			record := audit.NewRecord(start, callReq.Params.Name, callReq.Params.Arguments)
+
+			result, err := next(audit.NewContext(ctx, record), method, req)
+
+			record.Finish(time.Since(start), err != nil)
+			if writeErr := sink.Write(record); writeErr != nil {
+				slog.ErrorContext(ctx, "failed to write audit record", slog.String("error", writeErr.Error()))
+			}
+
+			return result, err
+		}
+	}
+}
+
+// metaCarrier exposes the string values of MCP request metadata to a trace context propagator.
+func metaCarrier(meta mcp.Meta) propagation.MapCarrier {
+	carrier := make(propagation.MapCarrier, len(meta))
+	for key, value := range meta {
+		if str, ok := value.(string); ok {
+			carrier[key] = str
+		}
+	}
+	return carrier
+}
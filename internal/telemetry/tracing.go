@@ -0,0 +1,59 @@
+// Package telemetry configures OpenTelemetry tracing.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/n-r-w/yandex-mcp/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+)
+
+// serviceName identifies the server in exported traces unless OTEL_SERVICE_NAME overrides it.
+const serviceName = "yandex-mcp"
+
+// ShutdownFunc flushes pending spans and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// SetupTracing installs the global tracer provider exporting spans over OTLP/HTTP when tracing is enabled.
+// The exporter endpoint, headers and sampling are configured by the standard OTEL_* environment variables.
+// When tracing is disabled, the global no-op provider stays in place and the returned function does nothing.
+func SetupTracing(ctx context.Context, cfg *config.Config, serviceVersion string) (ShutdownFunc, error) {
+	if !cfg.TracingEnabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName), semconv.ServiceVersion(serviceVersion)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		slog.Warn("opentelemetry error", slog.String("error", err.Error()))
+	}))
+
+	return provider.Shutdown, nil
+}
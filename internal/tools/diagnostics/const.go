@@ -0,0 +1,7 @@
+package diagnostics
+
+import "github.com/n-r-w/yandex-mcp/internal/domain"
+
+const (
+	toolAuthDiagnostics = domain.DiagnosticsToolAuth
+)
@@ -0,0 +1,37 @@
+package diagnostics
+
+import (
+	"time"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+)
+
+// mapAuthDiagnosticsToOutput converts domain auth diagnostics to the tool output.
+func mapAuthDiagnosticsToOutput(d domain.AuthDiagnostics) *authDiagnosticsOutputDTO {
+	out := &authDiagnosticsOutputDTO{
+		Method:         d.Method,
+		Chain:          d.Chain,
+		Resolved:       d.SelectedSource != "",
+		SelectedSource: d.SelectedSource,
+		ResolvedAt:     "",
+		Attempts:       nil,
+	}
+
+	if !d.ResolvedAt.IsZero() {
+		out.ResolvedAt = d.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+
+	if len(d.Attempts) > 0 {
+		out.Attempts = make([]authAttemptOutputDTO, 0, len(d.Attempts))
+		for _, attempt := range d.Attempts {
+			out.Attempts = append(out.Attempts, authAttemptOutputDTO{
+				Source:    attempt.Source,
+				Succeeded: attempt.Succeeded,
+				Skipped:   attempt.Skipped,
+				Reason:    attempt.Reason,
+			})
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,24 @@
+package diagnostics
+
+// authDiagnosticsInputDTO is the input for auth_diagnostics tool.
+type authDiagnosticsInputDTO struct {
+	Org string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
+}
+
+// authDiagnosticsOutputDTO is the output for auth_diagnostics tool.
+type authDiagnosticsOutputDTO struct {
+	Method         string                 `json:"method"`
+	Chain          []string               `json:"chain"`
+	Resolved       bool                   `json:"resolved"`
+	SelectedSource string                 `json:"selected_source,omitempty"`
+	ResolvedAt     string                 `json:"resolved_at,omitempty"`
+	Attempts       []authAttemptOutputDTO `json:"attempts,omitempty"`
+}
+
+// authAttemptOutputDTO describes a single credential source attempt.
+type authAttemptOutputDTO struct {
+	Source    string `json:"source"`
+	Succeeded bool   `json:"succeeded"`
+	Skipped   bool   `json:"skipped,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
@@ -0,0 +1,11 @@
+// Package diagnostics provides MCP tool handlers for server self-diagnostics.
+package diagnostics
+
+import "github.com/n-r-w/yandex-mcp/internal/domain"
+
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -source=interfaces.go -destination=mock_interfaces.go -package=diagnostics
+
+// IAuthDiagnosticsProvider exposes how API credentials were resolved.
+type IAuthDiagnosticsProvider interface {
+	AuthDiagnostics() domain.AuthDiagnostics
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=mock_interfaces.go -package=diagnostics
+//
+
+// Package diagnostics is a generated GoMock package.
+package diagnostics
+
+import (
+	reflect "reflect"
+
+	domain "github.com/n-r-w/yandex-mcp/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuthDiagnosticsProvider is a mock of IAuthDiagnosticsProvider interface.
+type MockIAuthDiagnosticsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthDiagnosticsProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockIAuthDiagnosticsProviderMockRecorder is the mock recorder for MockIAuthDiagnosticsProvider.
+type MockIAuthDiagnosticsProviderMockRecorder struct {
+	mock *MockIAuthDiagnosticsProvider
+}
+
+// NewMockIAuthDiagnosticsProvider creates a new mock instance.
+func NewMockIAuthDiagnosticsProvider(ctrl *gomock.Controller) *MockIAuthDiagnosticsProvider {
+	mock := &MockIAuthDiagnosticsProvider{ctrl: ctrl}
+	mock.recorder = &MockIAuthDiagnosticsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthDiagnosticsProvider) EXPECT() *MockIAuthDiagnosticsProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthDiagnostics mocks base method.
+func (m *MockIAuthDiagnosticsProvider) AuthDiagnostics() domain.AuthDiagnostics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthDiagnostics")
+	ret0, _ := ret[0].(domain.AuthDiagnostics)
+	return ret0
+}
+
+// AuthDiagnostics indicates an expected call of AuthDiagnostics.
+func (mr *MockIAuthDiagnosticsProviderMockRecorder) AuthDiagnostics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthDiagnostics", reflect.TypeOf((*MockIAuthDiagnosticsProvider)(nil).AuthDiagnostics))
+}
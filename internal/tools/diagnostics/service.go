@@ -0,0 +1,33 @@
+package diagnostics
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
+)
+
+// Registrator registers diagnostics tools with an MCP server.
+type Registrator struct {
+	authProviders *helpers.OrgRouter[IAuthDiagnosticsProvider]
+}
+
+// Compile-time assertion that Registrator implements server.IToolsRegistrator.
+var _ server.IToolsRegistrator = (*Registrator)(nil)
+
+// NewRegistrator creates a new diagnostics tools registrator.
+func NewRegistrator(authProviders *helpers.OrgRouter[IAuthDiagnosticsProvider]) *Registrator {
+	return &Registrator{
+		authProviders: authProviders,
+	}
+}
+
+// Register registers all diagnostics tools with the MCP server.
+func (r *Registrator) Register(srv *mcp.Server) error {
+	mcp.AddTool(srv, &mcp.Tool{ //nolint:exhaustruct // optional fields use defaults
+		Name: toolAuthDiagnostics,
+		Description: "Shows which credential source the server uses for Yandex APIs " +
+			"and why earlier sources in the chain were skipped or failed",
+	}, server.MakeHandler(r.getAuthDiagnostics))
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package diagnostics
+
+import (
+	"context"
+)
+
+// getAuthDiagnostics reports how API credentials were resolved.
+func (r *Registrator) getAuthDiagnostics(
+	_ context.Context, input authDiagnosticsInputDTO,
+) (*authDiagnosticsOutputDTO, error) {
+	provider, err := r.authProviders.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAuthDiagnosticsToOutput(provider.AuthDiagnostics()), nil
+}
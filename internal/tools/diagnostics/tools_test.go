@@ -0,0 +1,105 @@
+package diagnostics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
+)
+
+const testOrgName = "default"
+
+// singleOrg wraps a provider into a router with only the primary organization profile.
+func singleOrg(provider IAuthDiagnosticsProvider) *helpers.OrgRouter[IAuthDiagnosticsProvider] {
+	return helpers.NewOrgRouter(testOrgName, map[string]IAuthDiagnosticsProvider{testOrgName: provider})
+}
+
+func TestTools_GetAuthDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("maps resolved chain", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockProvider := NewMockIAuthDiagnosticsProvider(ctrl)
+		reg := NewRegistrator(singleOrg(mockProvider))
+
+		mockProvider.EXPECT().AuthDiagnostics().Return(domain.AuthDiagnostics{
+			Method:         "chain",
+			Chain:          []string{"oauth_token", "yc"},
+			SelectedSource: "yc",
+			ResolvedAt:     time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			Attempts: []domain.AuthSourceAttempt{
+				{Source: "oauth_token", Succeeded: false, Skipped: true, Reason: "YANDEX_OAUTH_TOKEN is not set"},
+				{Source: "yc", Succeeded: true, Skipped: false, Reason: ""},
+			},
+		})
+
+		result, err := reg.getAuthDiagnostics(t.Context(), authDiagnosticsInputDTO{Org: ""})
+		require.NoError(t, err)
+		assert.True(t, result.Resolved)
+		assert.Equal(t, "yc", result.SelectedSource)
+		assert.Equal(t, "2030-01-02T03:04:05Z", result.ResolvedAt)
+		require.Len(t, result.Attempts, 2)
+		assert.True(t, result.Attempts[0].Skipped)
+		assert.Equal(t, "YANDEX_OAUTH_TOKEN is not set", result.Attempts[0].Reason)
+		assert.True(t, result.Attempts[1].Succeeded)
+	})
+
+	t.Run("reports unresolved chain", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockProvider := NewMockIAuthDiagnosticsProvider(ctrl)
+		reg := NewRegistrator(singleOrg(mockProvider))
+
+		mockProvider.EXPECT().AuthDiagnostics().Return(domain.AuthDiagnostics{
+			Method:         "yc",
+			Chain:          []string{"yc"},
+			SelectedSource: "",
+			ResolvedAt:     time.Time{},
+			Attempts:       nil,
+		})
+
+		result, err := reg.getAuthDiagnostics(t.Context(), authDiagnosticsInputDTO{Org: ""})
+		require.NoError(t, err)
+		assert.False(t, result.Resolved)
+		assert.Empty(t, result.ResolvedAt)
+		assert.Empty(t, result.Attempts)
+	})
+	t.Run("routes to requested org", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		primary := NewMockIAuthDiagnosticsProvider(ctrl)
+		customer := NewMockIAuthDiagnosticsProvider(ctrl)
+		reg := NewRegistrator(helpers.NewOrgRouter(testOrgName, map[string]IAuthDiagnosticsProvider{
+			testOrgName: primary,
+			"customer":  customer,
+		}))
+
+		customer.EXPECT().AuthDiagnostics().Return(domain.AuthDiagnostics{
+			Method:         "oauth",
+			Chain:          []string{"oauth_token"},
+			SelectedSource: "oauth_token",
+			ResolvedAt:     time.Time{},
+			Attempts:       nil,
+		})
+
+		result, err := reg.getAuthDiagnostics(t.Context(), authDiagnosticsInputDTO{Org: "customer"})
+		require.NoError(t, err)
+		assert.Equal(t, "oauth", result.Method)
+	})
+
+	t.Run("rejects unknown org", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockProvider := NewMockIAuthDiagnosticsProvider(ctrl)
+		reg := NewRegistrator(singleOrg(mockProvider))
+
+		_, err := reg.getAuthDiagnostics(t.Context(), authDiagnosticsInputDTO{Org: "partner"})
+		require.ErrorContains(t, err, `unknown org "partner"`)
+	})
+}
@@ -10,6 +10,7 @@ var (
 		"marshal request body:",
 		"execute request:",
 		"get token:",
+		"wait for rate limit:",
 	}
 
 	safeContains = []string{
@@ -26,6 +26,16 @@ func ToSafeError(ctx context.Context, serviceName domain.Service, err error) (er
 		)
 	}
 
+	var unavailableErr domain.ServiceUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return unavailableErr
+	}
+
+	var tooLargeErr domain.ResponseTooLargeError
+	if errors.As(err, &tooLargeErr) {
+		return tooLargeErr
+	}
+
 	errMsg := err.Error()
 	lowerMsg := strings.ToLower(errMsg)
 
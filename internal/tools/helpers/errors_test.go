@@ -2,7 +2,9 @@ package helpers
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -72,6 +74,28 @@ func TestToSafeError(t *testing.T) {
 			serviceName:  domain.ServiceTracker,
 			wantContains: "get token:",
 		},
+		{
+			name:         "rate limit wait error",
+			err:          errors.New("wait for rate limit: context deadline exceeded"),
+			serviceName:  domain.ServiceTracker,
+			wantContains: "wait for rate limit:",
+		},
+		{
+			name:         "service unavailable error",
+			err:          domain.ServiceUnavailableError{Service: domain.ServiceWiki, RetryAfter: 12 * time.Second},
+			serviceName:  domain.ServiceWiki,
+			wantContains: "wiki: service temporarily unavailable, retry in 12s",
+		},
+		{
+			name: "response too large error",
+			err: fmt.Errorf("wrapped: %w", domain.ResponseTooLargeError{
+				Service:   domain.ServiceTracker,
+				Operation: "SearchIssues",
+				MaxBytes:  1024,
+			}),
+			serviceName:  domain.ServiceTracker,
+			wantContains: "tracker SearchIssues: response exceeds the limit of 1024 bytes; narrow the query",
+		},
 		{
 			name:         "unknown error",
 			err:          errors.New("something went wrong"),
@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// OrgRouter selects the adapter of the organization profile named by a tool's org argument.
+type OrgRouter[T any] struct {
+	primary  string
+	adapters map[string]T
+}
+
+// NewOrgRouter creates a router over per-profile adapters; primary is used when the org argument is empty.
+// The adapters map must contain the primary profile.
+func NewOrgRouter[T any](primary string, adapters map[string]T) *OrgRouter[T] {
+	return &OrgRouter[T]{
+		primary:  primary,
+		adapters: maps.Clone(adapters),
+	}
+}
+
+// Get returns the adapter for the requested organization profile.
+func (r *OrgRouter[T]) Get(org string) (T, error) {
+	name := strings.ToLower(strings.TrimSpace(org))
+	if name == "" {
+		name = r.primary
+	}
+
+	adapter, ok := r.adapters[name]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("unknown org %q, available: %s", org, strings.Join(r.Names(), ", "))
+	}
+
+	return adapter, nil
+}
+
+// Names returns the sorted names of all organization profiles.
+func (r *OrgRouter[T]) Names() []string {
+	return slices.Sorted(maps.Keys(r.adapters))
+}
@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRouter_Get(t *testing.T) {
+	t.Parallel()
+
+	router := NewOrgRouter("own", map[string]string{
+		"own":      "own-adapter",
+		"customer": "customer-adapter",
+	})
+
+	testCases := []struct {
+		name    string
+		org     string
+		want    string
+		wantErr string
+	}{
+		{name: "empty selects primary", org: "", want: "own-adapter", wantErr: ""},
+		{name: "explicit primary", org: "own", want: "own-adapter", wantErr: ""},
+		{name: "other profile", org: "customer", want: "customer-adapter", wantErr: ""},
+		{name: "case and spaces", org: " Customer ", want: "customer-adapter", wantErr: ""},
+		{name: "unknown profile", org: "partner", want: "", wantErr: `unknown org "partner", available: customer, own`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := router.Get(testCase.org)
+			if testCase.wantErr != "" {
+				require.EqualError(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -7,6 +7,7 @@ package tracker
 type getIssueInputDTO struct {
 	IssueID string `json:"issue_id_or_key" jsonschema:"Issue ID or key (e.g., TEST-1),required"`
 	Expand  string `json:"expand,omitempty" jsonschema:"Additional fields to include in response. Possible values: 'attachments' (attached files metadata). Example: 'attachments'"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // searchIssuesInputDTO is the input for tracker_issue_search tool.
@@ -71,17 +72,20 @@ type searchIssuesInputDTO struct {
 	// Returned in first scroll response, used in subsequent requests.
 	// Example: "6962987e5d10fe1be1cacfa9"
 	ScrollID string `json:"scroll_id,omitempty" jsonschema:"Scroll page identifier from previous scroll response. Use in 2nd and subsequent scroll requests to get next page of results. Obtained from 'scroll_id' field in first scroll response. Only for use with scroll pagination (>10,000 results). Example: '6962987e5d10fe1be1cacfa9'. Do not use with standard page/per_page pagination."`
+	Org      string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // countIssuesInputDTO is the input for tracker_issue_count tool.
 type countIssuesInputDTO struct {
 	Filter map[string]string `json:"filter,omitempty" jsonschema:"Field-based filter with key-value pairs. Values: simple values, special functions (me(), empty()), or comma-separated multiple values. Examples: {\"queue\": \"CP\"}, {\"status\": \"Open,In Progress\"}, {\"assignee\": \"me()\"}. IMPORTANT: Cannot be used together with 'query' - use either filter or query, not both."`
 	Query  string            `json:"query,omitempty" jsonschema:"Query language filter (Yandex Tracker syntax). Supports: field=value comparison, AND/OR/NOT operators, parentheses for grouping, date functions (today(), now(), today()-7d, today()+30d), special functions (me(), empty()). Supported fields: Queue, Status, Priority, Assignee, Author, Type, Resolution, Updated, Created, Due. Operators: : (exact match), >, <, >=, <= (numeric/dates). Examples: 'Status: Open', 'Assignee: me() AND Priority: Critical', '(Assignee: me() OR Author: me()) AND NOT Status: Closed', 'Updated: >today()-7d', 'Queue: CP OR BB AND NOT Status: Closed', 'Resolution: empty()'. IMPORTANT: Cannot be used together with 'filter' - use either filter or query, not both."`
+	Org    string            `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listTransitionsInputDTO is the input for tracker_issue_transitions_list tool.
 type listTransitionsInputDTO struct {
 	IssueID string `json:"issue_id_or_key" jsonschema:"Issue ID or key (e.g., TEST-1),required"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listQueuesInputDTO is the input for tracker_queues_list tool.
@@ -89,6 +93,7 @@ type listQueuesInputDTO struct {
 	Expand  string `json:"expand,omitempty" jsonschema:"Additional fields to include in response. Possible values: 'projects' (project information), 'components' (queue components), 'versions' (queue versions), 'types' (issue types), 'team' (team members), 'workflows' (workflow configurations), 'all' (all additional fields). Can be combined: 'projects,team'. Example: 'all'"`
 	PerPage int    `json:"per_page,omitempty" jsonschema:"Number of queues per page. Valid range: 1-50 (default: 50). Use for pagination when result set exceeds 50 queues."`
 	Page    int    `json:"page,omitempty" jsonschema:"Page number for pagination (1-based, default: 1). Use with per_page to navigate through large result sets."`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listCommentsInputDTO is the input for tracker_issue_comments_list tool.
@@ -97,11 +102,13 @@ type listCommentsInputDTO struct {
 	Expand  string `json:"expand,omitempty" jsonschema:"Additional fields to include in response. Possible values: 'attachments' (attached files metadata), 'html' (comment HTML markup), 'all' (all additional fields). Example: 'attachments,html'"`
 	PerPage int    `json:"per_page,omitempty" jsonschema:"Number of comments per page. Valid range: 1-50 (default: 50). Use for pagination when issue has many comments."`
 	ID      string `json:"id,omitempty" jsonschema:"Comment ID (string) after which the requested page will begin (for pagination). Use with per_page to navigate through comments chronologically. Example: '12345' (numeric ID as string)"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listAttachmentsInputDTO is the input for tracker_issue_attachments_list tool.
 type listAttachmentsInputDTO struct {
 	IssueID string `json:"issue_id_or_key" jsonschema:"Issue ID or key (e.g., TEST-1),required"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getAttachmentInputDTO is the input for tracker_issue_attachment_get tool.
@@ -112,6 +119,7 @@ type getAttachmentInputDTO struct {
 	SavePath     string `json:"save_path,omitempty" jsonschema:"Absolute path to save the attachment. Required when get_content is false. Exactly one of save_path or get_content must be provided. Example: '/Users/me/attachments/attachment.txt'."`
 	GetContent   bool   `json:"get_content,omitempty" jsonschema:"If true, returns text content in output. Allowed only for text file_name formats. Exactly one of save_path or get_content must be provided. Example: true"`
 	Override     bool   `json:"override,omitempty" jsonschema:"Overwrite existing file if true (default: false). Example: true"`
+	Org          string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getAttachmentPreviewInputDTO is the input for tracker_issue_attachment_preview_get tool.
@@ -120,45 +128,52 @@ type getAttachmentPreviewInputDTO struct {
 	AttachmentID string `json:"attachment_id" jsonschema:"Attachment ID as string. Example: '4159',required"`
 	SavePath     string `json:"save_path" jsonschema:"Absolute path to save the attachment preview. Example: '/Users/me/attachments/preview.png',required"`
 	Override     bool   `json:"override,omitempty" jsonschema:"Overwrite existing file if true (default: false). Example: true"`
+	Org          string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getQueueInputDTO is the input for tracker_queue_get tool.
 type getQueueInputDTO struct {
 	QueueID string `json:"queue_id_or_key" jsonschema:"Queue ID or key (e.g., MYQUEUE),required"`
 	Expand  string `json:"expand,omitempty" jsonschema:"Additional fields to include in response. Possible values: 'projects' (project information), 'components' (queue components), 'versions' (queue versions), 'types' (issue types), 'team' (team members), 'workflows' (workflow configurations), 'all' (all additional fields). Example: 'all'"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getCurrentUserInputDTO is the input for tracker_user_current tool.
 type getCurrentUserInputDTO struct {
-	// No input required
+	Org string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listUsersInputDTO is the input for tracker_users_list tool.
 type listUsersInputDTO struct {
-	PerPage int `json:"per_page,omitempty" jsonschema:"Number of users per page. Valid range: 1-50 (default: 50). Use for pagination when organization has many users."`
-	Page    int `json:"page,omitempty" jsonschema:"Page number for pagination (1-based, default: 1). Use with per_page to navigate through user list."`
+	PerPage int    `json:"per_page,omitempty" jsonschema:"Number of users per page. Valid range: 1-50 (default: 50). Use for pagination when organization has many users."`
+	Page    int    `json:"page,omitempty" jsonschema:"Page number for pagination (1-based, default: 1). Use with per_page to navigate through user list."`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getUserInputDTO is the input for tracker_user_get tool.
 type getUserInputDTO struct {
 	UserID string `json:"user_id" jsonschema:"User login or ID as string. Accepts either username/login (e.g., 'user.login') or numeric ID as string (e.g., '8000000000000015'),required"`
+	Org    string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listLinksInputDTO is the input for tracker_issue_links_list tool.
 type listLinksInputDTO struct {
 	IssueID string `json:"issue_id_or_key" jsonschema:"Issue ID or key (e.g., TEST-1),required"`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getChangelogInputDTO is the input for tracker_issue_changelog tool.
 type getChangelogInputDTO struct {
 	IssueID string `json:"issue_id_or_key" jsonschema:"Issue ID or key (e.g., TEST-1),required"`
 	PerPage int    `json:"per_page,omitempty" jsonschema:"Number of changelog entries per page. Valid range: 1-50 (default: 50). Use for pagination when issue has extensive history (>50 changes)."`
+	Org     string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listProjectCommentsInputDTO is the input for tracker_project_comments_list tool.
 type listProjectCommentsInputDTO struct {
 	ProjectID string `json:"project_id" jsonschema:"Project ID as string. Obtained from issue.project.primary.id or project list. Example: '114' (numeric ID as string),required"`
 	Expand    string `json:"expand,omitempty" jsonschema:"Additional fields to include in response. Possible values: 'all' (all additional fields), 'html' (comment HTML markup), 'attachments' (attached files metadata), 'reactions' (user reactions). Can be combined: 'html,attachments'. Example: 'all'"`
+	Org       string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // Output DTOs for tracker tools.
@@ -4,11 +4,12 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
 )
 
 // Registrator registers tracker tools with an MCP server.
 type Registrator struct {
-	adapter           ITrackerAdapter
+	adapters          *helpers.OrgRouter[ITrackerAdapter]
 	enabledTools      map[domain.TrackerTool]bool
 	allowedExtensions []string
 	allowedViewExts   []string
@@ -20,7 +21,7 @@ var _ server.IToolsRegistrator = (*Registrator)(nil)
 
 // NewRegistrator creates a new tracker tools registrator.
 func NewRegistrator(
-	adapter ITrackerAdapter,
+	adapters *helpers.OrgRouter[ITrackerAdapter],
 	enabledTools []domain.TrackerTool,
 	allowedExtensions []string,
 	allowedViewExts []string,
@@ -32,7 +33,7 @@ func NewRegistrator(
 	}
 
 	return &Registrator{
-		adapter:           adapter,
+		adapters:          adapters,
 		enabledTools:      toolMap,
 		allowedExtensions: normalizeAllowedExtensions(allowedExtensions),
 		allowedViewExts:   normalizeAllowedExtensions(allowedViewExts),
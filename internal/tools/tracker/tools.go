@@ -15,6 +15,11 @@ import (
 
 // getIssue retrieves a Tracker issue by its ID or key.
 func (r *Registrator) getIssue(ctx context.Context, input getIssueInputDTO) (*issueOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
@@ -23,7 +28,7 @@ func (r *Registrator) getIssue(ctx context.Context, input getIssueInputDTO) (*is
 		Expand: input.Expand,
 	}
 
-	issue, err := r.adapter.GetIssue(ctx, input.IssueID, opts)
+	issue, err := adapter.GetIssue(ctx, input.IssueID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -33,6 +38,11 @@ func (r *Registrator) getIssue(ctx context.Context, input getIssueInputDTO) (*is
 
 // searchIssues searches for Tracker issues using filter or query.
 func (r *Registrator) searchIssues(ctx context.Context, input searchIssuesInputDTO) (*searchIssuesOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.PerPage < 0 {
 		return nil, errors.New("per_page must be non-negative")
 	}
@@ -62,7 +72,7 @@ func (r *Registrator) searchIssues(ctx context.Context, input searchIssuesInputD
 		ScrollID:        input.ScrollID,
 	}
 
-	result, err := r.adapter.SearchIssues(ctx, opts)
+	result, err := adapter.SearchIssues(ctx, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -72,12 +82,17 @@ func (r *Registrator) searchIssues(ctx context.Context, input searchIssuesInputD
 
 // countIssues counts Tracker issues matching the filter or query.
 func (r *Registrator) countIssues(ctx context.Context, input countIssuesInputDTO) (*countIssuesOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := domain.TrackerCountIssuesOpts{
 		Filter: input.Filter,
 		Query:  input.Query,
 	}
 
-	count, err := r.adapter.CountIssues(ctx, opts)
+	count, err := adapter.CountIssues(ctx, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -89,11 +104,16 @@ func (r *Registrator) countIssues(ctx context.Context, input countIssuesInputDTO
 func (r *Registrator) listTransitions(
 	ctx context.Context, input listTransitionsInputDTO,
 ) (*transitionsListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
 
-	transitions, err := r.adapter.ListIssueTransitions(ctx, input.IssueID)
+	transitions, err := adapter.ListIssueTransitions(ctx, input.IssueID)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -103,6 +123,11 @@ func (r *Registrator) listTransitions(
 
 // listQueues lists all Tracker queues.
 func (r *Registrator) listQueues(ctx context.Context, input listQueuesInputDTO) (*queuesListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.PerPage < 0 {
 		return nil, errors.New("per_page must be non-negative")
 	}
@@ -116,7 +141,7 @@ func (r *Registrator) listQueues(ctx context.Context, input listQueuesInputDTO)
 		Page:    input.Page,
 	}
 
-	result, err := r.adapter.ListQueues(ctx, opts)
+	result, err := adapter.ListQueues(ctx, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -126,6 +151,11 @@ func (r *Registrator) listQueues(ctx context.Context, input listQueuesInputDTO)
 
 // listComments lists comments for a Tracker issue.
 func (r *Registrator) listComments(ctx context.Context, input listCommentsInputDTO) (*commentsListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
@@ -138,7 +168,7 @@ func (r *Registrator) listComments(ctx context.Context, input listCommentsInputD
 		ID:      input.ID,
 	}
 
-	result, err := r.adapter.ListIssueComments(ctx, input.IssueID, opts)
+	result, err := adapter.ListIssueComments(ctx, input.IssueID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -150,11 +180,16 @@ func (r *Registrator) listComments(ctx context.Context, input listCommentsInputD
 func (r *Registrator) listAttachments(
 	ctx context.Context, input listAttachmentsInputDTO,
 ) (*attachmentsListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
 
-	attachments, err := r.adapter.ListIssueAttachments(ctx, input.IssueID)
+	attachments, err := adapter.ListIssueAttachments(ctx, input.IssueID)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -166,6 +201,11 @@ func (r *Registrator) listAttachments(
 func (r *Registrator) getAttachment(
 	ctx context.Context, input getAttachmentInputDTO,
 ) (*attachmentContentOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
@@ -182,7 +222,7 @@ func (r *Registrator) getAttachment(
 		return nil, errors.New("save_path and get_content cannot be used together")
 	}
 	if input.GetContent {
-		if err := r.validateAttachmentViewExtension(input.FileName); err != nil {
+		if err = r.validateAttachmentViewExtension(input.FileName); err != nil {
 			return nil, err
 		}
 	}
@@ -192,16 +232,15 @@ func (r *Registrator) getAttachment(
 		savedPath string
 	)
 	if input.SavePath != "" {
-		var err error
 		fullPath, savedPath, err = r.prepareSavePath(ctx, input.SavePath, input.Override)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if input.SavePath != "" {
-		stream, err := r.adapter.GetIssueAttachmentStream(ctx, input.IssueID, input.AttachmentID, input.FileName)
-		if err != nil {
-			return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
+		stream, streamErr := adapter.GetIssueAttachmentStream(ctx, input.IssueID, input.AttachmentID, input.FileName)
+		if streamErr != nil {
+			return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, streamErr)
 		}
 		if stream == nil || stream.Stream == nil {
 			return nil, r.logError(ctx, errors.New("attachment stream is empty"))
@@ -223,7 +262,7 @@ func (r *Registrator) getAttachment(
 		}, nil
 	}
 
-	content, err := r.adapter.GetIssueAttachment(ctx, input.IssueID, input.AttachmentID, input.FileName)
+	content, err := adapter.GetIssueAttachment(ctx, input.IssueID, input.AttachmentID, input.FileName)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -239,6 +278,11 @@ func (r *Registrator) getAttachment(
 func (r *Registrator) getAttachmentPreview(
 	ctx context.Context, input getAttachmentPreviewInputDTO,
 ) (*attachmentContentOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
@@ -253,7 +297,7 @@ func (r *Registrator) getAttachmentPreview(
 	if err != nil {
 		return nil, err
 	}
-	stream, err := r.adapter.GetIssueAttachmentPreviewStream(ctx, input.IssueID, input.AttachmentID)
+	stream, err := adapter.GetIssueAttachmentPreviewStream(ctx, input.IssueID, input.AttachmentID)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -635,6 +679,11 @@ func (r *Registrator) writeAttachmentStream(fullPath string, override bool, read
 
 // getQueue gets a queue by ID or key.
 func (r *Registrator) getQueue(ctx context.Context, input getQueueInputDTO) (*queueDetailOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.QueueID == "" {
 		return nil, errors.New("queue_id_or_key is required")
 	}
@@ -643,7 +692,7 @@ func (r *Registrator) getQueue(ctx context.Context, input getQueueInputDTO) (*qu
 		Expand: input.Expand,
 	}
 
-	queue, err := r.adapter.GetQueue(ctx, input.QueueID, opts)
+	queue, err := adapter.GetQueue(ctx, input.QueueID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -652,8 +701,13 @@ func (r *Registrator) getQueue(ctx context.Context, input getQueueInputDTO) (*qu
 }
 
 // getCurrentUser gets the current authenticated user.
-func (r *Registrator) getCurrentUser(ctx context.Context, _ getCurrentUserInputDTO) (*userDetailOutputDTO, error) {
-	user, err := r.adapter.GetCurrentUser(ctx)
+func (r *Registrator) getCurrentUser(ctx context.Context, input getCurrentUserInputDTO) (*userDetailOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := adapter.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -663,6 +717,11 @@ func (r *Registrator) getCurrentUser(ctx context.Context, _ getCurrentUserInputD
 
 // listUsers lists users with optional pagination.
 func (r *Registrator) listUsers(ctx context.Context, input listUsersInputDTO) (*usersListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.PerPage < 0 {
 		return nil, errors.New("per_page must be non-negative")
 	}
@@ -675,7 +734,7 @@ func (r *Registrator) listUsers(ctx context.Context, input listUsersInputDTO) (*
 		Page:    input.Page,
 	}
 
-	result, err := r.adapter.ListUsers(ctx, opts)
+	result, err := adapter.ListUsers(ctx, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -685,11 +744,16 @@ func (r *Registrator) listUsers(ctx context.Context, input listUsersInputDTO) (*
 
 // getUser gets a user by ID or login.
 func (r *Registrator) getUser(ctx context.Context, input getUserInputDTO) (*userDetailOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.UserID == "" {
 		return nil, errors.New("user_id is required")
 	}
 
-	user, err := r.adapter.GetUser(ctx, input.UserID)
+	user, err := adapter.GetUser(ctx, input.UserID)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -699,11 +763,16 @@ func (r *Registrator) getUser(ctx context.Context, input getUserInputDTO) (*user
 
 // listLinks lists all links for an issue.
 func (r *Registrator) listLinks(ctx context.Context, input listLinksInputDTO) (*linksListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
 
-	links, err := r.adapter.ListIssueLinks(ctx, input.IssueID)
+	links, err := adapter.ListIssueLinks(ctx, input.IssueID)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -713,6 +782,11 @@ func (r *Registrator) listLinks(ctx context.Context, input listLinksInputDTO) (*
 
 // getChangelog gets the changelog for an issue.
 func (r *Registrator) getChangelog(ctx context.Context, input getChangelogInputDTO) (*changelogOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.IssueID == "" {
 		return nil, errors.New("issue_id_or_key is required")
 	}
@@ -724,7 +798,7 @@ func (r *Registrator) getChangelog(ctx context.Context, input getChangelogInputD
 		PerPage: input.PerPage,
 	}
 
-	entries, err := r.adapter.GetIssueChangelog(ctx, input.IssueID, opts)
+	entries, err := adapter.GetIssueChangelog(ctx, input.IssueID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -736,6 +810,11 @@ func (r *Registrator) getChangelog(ctx context.Context, input getChangelogInputD
 func (r *Registrator) listProjectComments(
 	ctx context.Context, input listProjectCommentsInputDTO,
 ) (*projectCommentsListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.ProjectID == "" {
 		return nil, errors.New("project_id is required")
 	}
@@ -744,7 +823,7 @@ func (r *Registrator) listProjectComments(
 		Expand: input.Expand,
 	}
 
-	comments, err := r.adapter.ListProjectComments(ctx, input.ProjectID, opts)
+	comments, err := adapter.ListProjectComments(ctx, input.ProjectID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceTracker, err)
 	}
@@ -14,8 +14,16 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
 )
 
+const testOrgName = "default"
+
+// singleOrg wraps an adapter into a router with only the primary organization profile.
+func singleOrg(adapter ITrackerAdapter) *helpers.OrgRouter[ITrackerAdapter] {
+	return helpers.NewOrgRouter(testOrgName, map[string]ITrackerAdapter{testOrgName: adapter})
+}
+
 var (
 	defaultAttachExtensions = []string{"txt", "png"}
 	defaultAttachViewExts   = []string{"txt"}
@@ -29,7 +37,7 @@ func TestTools_GetIssue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getIssue(t.Context(), getIssueInputDTO{})
 		require.Error(t, err)
@@ -40,7 +48,7 @@ func TestTools_GetIssue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedIssue := &domain.TrackerIssue{
 			Self:    "https://api.tracker/v3/issues/TEST-123",
@@ -70,7 +78,7 @@ func TestTools_GetIssue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.UpstreamError{
 			Service:    domain.ServiceTracker,
@@ -98,7 +106,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.searchIssues(t.Context(), searchIssuesInputDTO{PerPage: -1})
 		require.Error(t, err)
@@ -109,7 +117,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.searchIssues(t.Context(), searchIssuesInputDTO{Page: -1})
 		require.Error(t, err)
@@ -120,7 +128,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.searchIssues(t.Context(), searchIssuesInputDTO{PerScroll: -1})
 		require.Error(t, err)
@@ -131,7 +139,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.searchIssues(t.Context(), searchIssuesInputDTO{PerScroll: 1001})
 		require.Error(t, err)
@@ -142,7 +150,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.searchIssues(t.Context(), searchIssuesInputDTO{ScrollTTLMillis: -1})
 		require.Error(t, err)
@@ -153,7 +161,7 @@ func TestTools_SearchIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedResult := &domain.TrackerIssuesPage{
 			Issues: []domain.TrackerIssue{
@@ -213,7 +221,7 @@ func TestTools_CountIssues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		mockAdapter.EXPECT().
 			CountIssues(gomock.Any(), domain.TrackerCountIssuesOpts{
@@ -238,7 +246,7 @@ func TestTools_ListTransitions(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listTransitions(t.Context(), listTransitionsInputDTO{})
 		require.Error(t, err)
@@ -249,7 +257,7 @@ func TestTools_ListTransitions(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedTransitions := []domain.TrackerTransition{
 			{
@@ -288,7 +296,7 @@ func TestTools_ListQueues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listQueues(t.Context(), listQueuesInputDTO{PerPage: -1})
 		require.Error(t, err)
@@ -299,7 +307,7 @@ func TestTools_ListQueues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listQueues(t.Context(), listQueuesInputDTO{Page: -1})
 		require.Error(t, err)
@@ -310,7 +318,7 @@ func TestTools_ListQueues(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedResult := &domain.TrackerQueuesPage{
 			Queues: []domain.TrackerQueue{
@@ -349,7 +357,7 @@ func TestTools_ListComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listComments(t.Context(), listCommentsInputDTO{})
 		require.Error(t, err)
@@ -360,7 +368,7 @@ func TestTools_ListComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listComments(t.Context(), listCommentsInputDTO{
 			IssueID: "TEST-1",
@@ -374,7 +382,7 @@ func TestTools_ListComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedResult := &domain.TrackerCommentsPage{
 			Comments: []domain.TrackerComment{
@@ -424,7 +432,7 @@ func TestTools_ErrorShaping(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -452,7 +460,7 @@ func TestTools_ErrorShaping(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		// Simulate an error that contains sensitive data
 		sensitiveErr := errors.New("connection failed: Authorization header: Bearer secret-token-123")
@@ -478,7 +486,7 @@ func TestTools_MapsAllIssueFields(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedIssue := &domain.TrackerIssue{
 			Self:            "https://api/issues/1",
@@ -553,7 +561,7 @@ func TestTools_ListAttachments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listAttachments(t.Context(), listAttachmentsInputDTO{})
 		require.Error(t, err)
@@ -564,7 +572,7 @@ func TestTools_ListAttachments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedAttachments := []domain.TrackerAttachment{
 			{
@@ -607,7 +615,7 @@ func TestTools_ListAttachments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		mockAdapter.EXPECT().
 			ListIssueAttachments(gomock.Any(), "TEST-2").
@@ -624,7 +632,7 @@ func TestTools_ListAttachments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -657,7 +665,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{})
 		require.Error(t, err)
@@ -668,7 +676,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID: "TEST-1",
@@ -681,7 +689,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID:      "TEST-1",
@@ -695,7 +703,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID:      "TEST-1",
@@ -710,7 +718,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID:      "TEST-1",
@@ -727,7 +735,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID:      "TEST-1",
@@ -744,7 +752,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachment(t.Context(), getAttachmentInputDTO{
 			IssueID:      "TEST-1",
@@ -761,7 +769,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		tmpDir := t.TempDir()
 		reg.allowedDirs = []string{tmpDir}
 
@@ -781,7 +789,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		allowedDir := t.TempDir()
 		outsideDir := t.TempDir()
 		reg.allowedDirs = []string{allowedDir}
@@ -802,7 +810,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		allowedDir := t.TempDir()
 		outsideDir := t.TempDir()
 		reg.allowedDirs = []string{allowedDir}
@@ -828,7 +836,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		homeDir, err := os.UserHomeDir()
 		require.NoError(t, err)
 		outsidePath := filepath.Join(filepath.Dir(homeDir), "tmp", "attachment.txt")
@@ -849,7 +857,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		homeDir, err := os.UserHomeDir()
 		require.NoError(t, err)
 		hiddenPath := filepath.Join(homeDir, ".ssh", "attachment.txt")
@@ -870,7 +878,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		baseDir := t.TempDir()
 		reg.allowedDirs = []string{baseDir}
 		savePath := filepath.Join(baseDir, "attachments", "attachment.txt")
@@ -907,7 +915,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		payload := []byte("inline text")
 		expected := &domain.TrackerAttachmentContent{
@@ -938,7 +946,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		baseDir := t.TempDir()
 		reg.allowedDirs = []string{baseDir}
 		savePath := filepath.Join(baseDir, "attachments", "existing.txt")
@@ -961,7 +969,7 @@ func TestTools_GetAttachment(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		baseDir := t.TempDir()
 		reg.allowedDirs = []string{baseDir}
 
@@ -999,7 +1007,7 @@ func TestTools_GetAttachmentPreview(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachmentPreview(t.Context(), getAttachmentPreviewInputDTO{})
 		require.Error(t, err)
@@ -1010,7 +1018,7 @@ func TestTools_GetAttachmentPreview(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachmentPreview(t.Context(), getAttachmentPreviewInputDTO{
 			IssueID: "TEST-1",
@@ -1023,7 +1031,7 @@ func TestTools_GetAttachmentPreview(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getAttachmentPreview(t.Context(), getAttachmentPreviewInputDTO{
 			IssueID:      "TEST-1",
@@ -1037,7 +1045,7 @@ func TestTools_GetAttachmentPreview(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		baseDir := t.TempDir()
 		reg.allowedDirs = []string{baseDir}
 		savePath := filepath.Join(baseDir, "attachments", "preview.png")
@@ -1071,7 +1079,7 @@ func TestTools_GetAttachmentPreview(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 		baseDir := t.TempDir()
 		reg.allowedDirs = []string{baseDir}
 
@@ -1108,7 +1116,7 @@ func TestTools_GetQueue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getQueue(t.Context(), getQueueInputDTO{})
 		require.Error(t, err)
@@ -1119,7 +1127,7 @@ func TestTools_GetQueue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedQueue := &domain.TrackerQueueDetail{
 			Self:        "https://api/v3/queues/TEST",
@@ -1149,7 +1157,7 @@ func TestTools_GetQueue(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1182,7 +1190,7 @@ func TestTools_GetCurrentUser(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedUser := &domain.TrackerUserDetail{
 			Self:       "https://api/v3/users/1",
@@ -1214,7 +1222,7 @@ func TestTools_GetCurrentUser(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1245,7 +1253,7 @@ func TestTools_ListUsers(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listUsers(t.Context(), listUsersInputDTO{PerPage: -1})
 		require.Error(t, err)
@@ -1256,7 +1264,7 @@ func TestTools_ListUsers(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listUsers(t.Context(), listUsersInputDTO{Page: -1})
 		require.Error(t, err)
@@ -1267,7 +1275,7 @@ func TestTools_ListUsers(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedResult := &domain.TrackerUsersPage{
 			Users: []domain.TrackerUserDetail{
@@ -1308,7 +1316,7 @@ func TestTools_ListUsers(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1339,7 +1347,7 @@ func TestTools_GetUser(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getUser(t.Context(), getUserInputDTO{})
 		require.Error(t, err)
@@ -1350,7 +1358,7 @@ func TestTools_GetUser(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedUser := &domain.TrackerUserDetail{
 			Self:    "https://api.tracker/v3/users/testuser",
@@ -1377,7 +1385,7 @@ func TestTools_GetUser(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1410,7 +1418,7 @@ func TestTools_ListLinks(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listLinks(t.Context(), listLinksInputDTO{})
 		require.Error(t, err)
@@ -1421,7 +1429,7 @@ func TestTools_ListLinks(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedLinks := []domain.TrackerLink{
 			{
@@ -1459,7 +1467,7 @@ func TestTools_ListLinks(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1492,7 +1500,7 @@ func TestTools_GetChangelog(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getChangelog(t.Context(), getChangelogInputDTO{})
 		require.Error(t, err)
@@ -1503,7 +1511,7 @@ func TestTools_GetChangelog(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.getChangelog(t.Context(), getChangelogInputDTO{
 			IssueID: "TEST-1",
@@ -1517,7 +1525,7 @@ func TestTools_GetChangelog(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedEntries := []domain.TrackerChangelogEntry{
 			{
@@ -1551,7 +1559,7 @@ func TestTools_GetChangelog(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1584,7 +1592,7 @@ func TestTools_ListProjectComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		_, err := reg.listProjectComments(t.Context(), listProjectCommentsInputDTO{})
 		require.Error(t, err)
@@ -1595,7 +1603,7 @@ func TestTools_ListProjectComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		expectedComments := []domain.TrackerProjectComment{
 			{
@@ -1627,7 +1635,7 @@ func TestTools_ListProjectComments(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockITrackerAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceTracker,
@@ -1652,3 +1660,45 @@ func TestTools_ListProjectComments(t *testing.T) {
 		assert.NotContains(t, errStr, "secrets")
 	})
 }
+
+func TestTools_OrgRouting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("routes call to requested org adapter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		primary := NewMockITrackerAdapter(ctrl)
+		customer := NewMockITrackerAdapter(ctrl)
+		router := helpers.NewOrgRouter(testOrgName, map[string]ITrackerAdapter{
+			testOrgName: primary,
+			"customer":  customer,
+		})
+		reg := NewRegistrator(router, domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+
+		customer.EXPECT().
+			GetCurrentUser(gomock.Any()).
+			Return(&domain.TrackerUserDetail{Login: "customer.user"}, nil)
+		primary.EXPECT().
+			GetCurrentUser(gomock.Any()).
+			Return(&domain.TrackerUserDetail{Login: "own.user"}, nil)
+
+		result, err := reg.getCurrentUser(t.Context(), getCurrentUserInputDTO{Org: "customer"})
+		require.NoError(t, err)
+		assert.Equal(t, "customer.user", result.Login)
+
+		result, err = reg.getCurrentUser(t.Context(), getCurrentUserInputDTO{})
+		require.NoError(t, err)
+		assert.Equal(t, "own.user", result.Login)
+	})
+
+	t.Run("rejects unknown org without calling adapter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockAdapter := NewMockITrackerAdapter(ctrl)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.TrackerAllTools(), defaultAttachExtensions, defaultAttachViewExts, defaultAttachDirs)
+
+		_, err := reg.getIssue(t.Context(), getIssueInputDTO{IssueID: "TEST-1", Org: "partner"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown org "partner"`)
+	})
+}
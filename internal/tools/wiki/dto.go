@@ -9,6 +9,7 @@ type getPageBySlugInputDTO struct {
 	Fields          []string `json:"fields,omitempty" jsonschema:"Additional fields to include in the response. Allowed values: attributes, breadcrumbs, content, redirect"`
 	RevisionID      string   `json:"revision_id,omitempty" jsonschema:"Fetch specific page revision by ID (string)"`
 	RaiseOnRedirect bool     `json:"raise_on_redirect,omitempty" jsonschema:"Return error if page redirects instead of following redirect"`
+	Org             string   `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getPageByIDInputDTO is the input for wiki_page_get_by_id tool.
@@ -17,6 +18,7 @@ type getPageByIDInputDTO struct {
 	Fields          []string `json:"fields,omitempty" jsonschema:"Additional fields to include in the response. Allowed values: attributes, breadcrumbs, content, redirect"`
 	RevisionID      string   `json:"revision_id,omitempty" jsonschema:"Fetch specific page revision by ID (string)"`
 	RaiseOnRedirect bool     `json:"raise_on_redirect,omitempty" jsonschema:"Return error if page redirects instead of following redirect"`
+	Org             string   `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listResourcesInputDTO is the input for wiki_page_resources_list tool.
@@ -28,6 +30,7 @@ type listResourcesInputDTO struct {
 	OrderDirection string `json:"order_direction,omitempty" jsonschema:"Order direction. Possible values: asc, desc. Default: asc"`
 	Q              string `json:"q,omitempty" jsonschema:"Filter resources by title. Maximum: 255 chars"`
 	Types          string `json:"types,omitempty" jsonschema:"Resource types filter. Possible values: attachment, sharepoint_resource, grid. Can be comma-separated for multiple types"`
+	Org            string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // listGridsInputDTO is the input for wiki_page_grids_list tool.
@@ -37,6 +40,7 @@ type listGridsInputDTO struct {
 	PageSize       int    `json:"page_size,omitempty" jsonschema:"Number of items per page. Valid range: 1-50. Default: 25"`
 	OrderBy        string `json:"order_by,omitempty" jsonschema:"Field to order by. Possible values: title, created_at"`
 	OrderDirection string `json:"order_direction,omitempty" jsonschema:"Order direction. Possible values: asc, desc. Default: asc"`
+	Org            string `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // getGridInputDTO is the input for wiki_grid_get tool.
@@ -48,6 +52,7 @@ type getGridInputDTO struct {
 	OnlyRows string   `json:"only_rows,omitempty" jsonschema:"Return only specified rows (comma-separated row IDs)"`
 	Revision string   `json:"revision,omitempty" jsonschema:"Grid revision number for optimistic locking and historical versions"`
 	Sort     string   `json:"sort,omitempty" jsonschema:"Sort expression to order rows by column"`
+	Org      string   `json:"org,omitempty" jsonschema:"Organization profile name. Omit to use the primary profile"`
 }
 
 // Output DTOs for wiki tools.
@@ -4,11 +4,12 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/n-r-w/yandex-mcp/internal/domain"
 	"github.com/n-r-w/yandex-mcp/internal/server"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
 )
 
 // Registrator registers wiki tools with an MCP server.
 type Registrator struct {
-	adapter      IWikiAdapter
+	adapters     *helpers.OrgRouter[IWikiAdapter]
 	enabledTools map[domain.WikiTool]bool
 }
 
@@ -16,14 +17,14 @@ type Registrator struct {
 var _ server.IToolsRegistrator = (*Registrator)(nil)
 
 // NewRegistrator creates a new wiki tools registrator.
-func NewRegistrator(adapter IWikiAdapter, enabledTools []domain.WikiTool) *Registrator {
+func NewRegistrator(adapters *helpers.OrgRouter[IWikiAdapter], enabledTools []domain.WikiTool) *Registrator {
 	toolMap := make(map[domain.WikiTool]bool, len(enabledTools))
 	for _, t := range enabledTools {
 		toolMap[t] = true
 	}
 
 	return &Registrator{
-		adapter:      adapter,
+		adapters:     adapters,
 		enabledTools: toolMap,
 	}
 }
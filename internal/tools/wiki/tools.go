@@ -11,6 +11,11 @@ import (
 
 // getPageBySlug retrieves a Wiki page by its slug.
 func (r *Registrator) getPageBySlug(ctx context.Context, input getPageBySlugInputDTO) (*pageOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.Slug == "" {
 		return nil, errors.New("slug is required")
 	}
@@ -21,7 +26,7 @@ func (r *Registrator) getPageBySlug(ctx context.Context, input getPageBySlugInpu
 		RaiseOnRedirect: input.RaiseOnRedirect,
 	}
 
-	page, err := r.adapter.GetPageBySlug(ctx, input.Slug, opts)
+	page, err := adapter.GetPageBySlug(ctx, input.Slug, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceWiki, err)
 	}
@@ -31,13 +36,18 @@ func (r *Registrator) getPageBySlug(ctx context.Context, input getPageBySlugInpu
 
 // getPageByID retrieves a Wiki page by its ID.
 func (r *Registrator) getPageByID(ctx context.Context, input getPageByIDInputDTO) (*pageOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := domain.WikiGetPageOpts{
 		Fields:          input.Fields,
 		RevisionID:      input.RevisionID,
 		RaiseOnRedirect: input.RaiseOnRedirect,
 	}
 
-	page, err := r.adapter.GetPageByID(ctx, input.PageID, opts)
+	page, err := adapter.GetPageByID(ctx, input.PageID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceWiki, err)
 	}
@@ -47,6 +57,11 @@ func (r *Registrator) getPageByID(ctx context.Context, input getPageByIDInputDTO
 
 // listResources lists resources (attachments, grids) for a page.
 func (r *Registrator) listResources(ctx context.Context, input listResourcesInputDTO) (*resourcesListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.PageSize < 0 {
 		return nil, errors.New("page_size must be non-negative")
 	}
@@ -64,7 +79,7 @@ func (r *Registrator) listResources(ctx context.Context, input listResourcesInpu
 		Types:          input.Types,
 	}
 
-	result, err := r.adapter.ListPageResources(ctx, input.PageID, opts)
+	result, err := adapter.ListPageResources(ctx, input.PageID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceWiki, err)
 	}
@@ -74,6 +89,11 @@ func (r *Registrator) listResources(ctx context.Context, input listResourcesInpu
 
 // listGrids lists dynamic tables (grids) for a page.
 func (r *Registrator) listGrids(ctx context.Context, input listGridsInputDTO) (*gridsListOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.PageSize < 0 {
 		return nil, errors.New("page_size must be non-negative")
 	}
@@ -89,7 +109,7 @@ func (r *Registrator) listGrids(ctx context.Context, input listGridsInputDTO) (*
 		OrderDirection: input.OrderDirection,
 	}
 
-	result, err := r.adapter.ListPageGrids(ctx, input.PageID, opts)
+	result, err := adapter.ListPageGrids(ctx, input.PageID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceWiki, err)
 	}
@@ -99,6 +119,11 @@ func (r *Registrator) listGrids(ctx context.Context, input listGridsInputDTO) (*
 
 // getGrid retrieves a dynamic table by its ID.
 func (r *Registrator) getGrid(ctx context.Context, input getGridInputDTO) (*gridOutputDTO, error) {
+	adapter, err := r.adapters.Get(input.Org)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.GridID == "" {
 		return nil, errors.New("grid_id is required")
 	}
@@ -112,7 +137,7 @@ func (r *Registrator) getGrid(ctx context.Context, input getGridInputDTO) (*grid
 		Sort:     input.Sort,
 	}
 
-	grid, err := r.adapter.GetGridByID(ctx, input.GridID, opts)
+	grid, err := adapter.GetGridByID(ctx, input.GridID, opts)
 	if err != nil {
 		return nil, helpers.ToSafeError(ctx, domain.ServiceWiki, err)
 	}
@@ -10,8 +10,16 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/n-r-w/yandex-mcp/internal/domain"
+	"github.com/n-r-w/yandex-mcp/internal/tools/helpers"
 )
 
+const testOrgName = "default"
+
+// singleOrg wraps an adapter into a router with only the primary organization profile.
+func singleOrg(adapter IWikiAdapter) *helpers.OrgRouter[IWikiAdapter] {
+	return helpers.NewOrgRouter(testOrgName, map[string]IWikiAdapter{testOrgName: adapter})
+}
+
 func TestTools_GetPageBySlug(t *testing.T) {
 	t.Parallel()
 
@@ -19,7 +27,7 @@ func TestTools_GetPageBySlug(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.getPageBySlug(t.Context(), getPageBySlugInputDTO{})
 		require.Error(t, err)
@@ -30,7 +38,7 @@ func TestTools_GetPageBySlug(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedPage := &domain.WikiPage{
 			ID:       "123",
@@ -60,7 +68,7 @@ func TestTools_GetPageBySlug(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		upstreamErr := domain.UpstreamError{
 			Service:    domain.ServiceWiki,
@@ -89,7 +97,7 @@ func TestTools_GetPageByID(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedPage := &domain.WikiPage{
 			ID:       "456",
@@ -115,7 +123,7 @@ func TestTools_GetPageByID(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedPage := &domain.WikiPage{
 			ID:    "789",
@@ -148,7 +156,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.listResources(t.Context(), listResourcesInputDTO{
 			PageID:   "123",
@@ -162,7 +170,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.listResources(t.Context(), listResourcesInputDTO{
 			PageID:   "123",
@@ -176,7 +184,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedResult := &domain.WikiResourcesPage{
 			Resources: []domain.WikiResource{
@@ -223,7 +231,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedResult := &domain.WikiResourcesPage{
 			Resources: []domain.WikiResource{
@@ -269,7 +277,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedResult := &domain.WikiResourcesPage{
 			Resources: []domain.WikiResource{
@@ -309,7 +317,7 @@ func TestTools_ListResources(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedResult := &domain.WikiResourcesPage{
 			Resources: []domain.WikiResource{
@@ -351,7 +359,7 @@ func TestTools_ListGrids(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.listGrids(t.Context(), listGridsInputDTO{
 			PageID:   "123",
@@ -365,7 +373,7 @@ func TestTools_ListGrids(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.listGrids(t.Context(), listGridsInputDTO{
 			PageID:   "123",
@@ -379,7 +387,7 @@ func TestTools_ListGrids(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedResult := &domain.WikiGridsPage{
 			Grids: []domain.WikiGridSummary{
@@ -415,7 +423,7 @@ func TestTools_GetGrid(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		_, err := reg.getGrid(t.Context(), getGridInputDTO{})
 		require.Error(t, err)
@@ -426,7 +434,7 @@ func TestTools_GetGrid(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedGrid := &domain.WikiGrid{
 			ID:       "grid123",
@@ -468,7 +476,7 @@ func TestTools_GetGrid(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedGrid := &domain.WikiGrid{
 			ID:    "gridWithAttrs",
@@ -495,7 +503,7 @@ func TestTools_GetGrid(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		expectedGrid := &domain.WikiGrid{
 			ID:       "gridCellTypes",
@@ -575,7 +583,7 @@ func TestTools_ErrorShaping(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		upstreamErr := domain.NewUpstreamError(
 			domain.ServiceWiki,
@@ -603,7 +611,7 @@ func TestTools_ErrorShaping(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		mockAdapter := NewMockIWikiAdapter(ctrl)
-		reg := NewRegistrator(mockAdapter, domain.WikiAllTools())
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
 
 		// Simulate an error that contains sensitive data
 		sensitiveErr := errors.New("connection failed: Authorization header: Bearer secret-token-123")
@@ -621,3 +629,37 @@ func TestTools_ErrorShaping(t *testing.T) {
 		assert.NotContains(t, errStr, "secret-token-123")
 	})
 }
+
+func TestTools_OrgRouting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("routes call to requested org adapter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		primary := NewMockIWikiAdapter(ctrl)
+		customer := NewMockIWikiAdapter(ctrl)
+		reg := NewRegistrator(helpers.NewOrgRouter(testOrgName, map[string]IWikiAdapter{
+			testOrgName: primary,
+			"customer":  customer,
+		}), domain.WikiAllTools())
+
+		customer.EXPECT().
+			GetPageByID(gomock.Any(), "42", domain.WikiGetPageOpts{}).
+			Return(&domain.WikiPage{ID: "42", Title: "Customer Page"}, nil)
+
+		result, err := reg.getPageByID(t.Context(), getPageByIDInputDTO{PageID: "42", Org: "customer"})
+		require.NoError(t, err)
+		assert.Equal(t, "Customer Page", result.Title)
+	})
+
+	t.Run("rejects unknown org without calling adapter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockAdapter := NewMockIWikiAdapter(ctrl)
+		reg := NewRegistrator(singleOrg(mockAdapter), domain.WikiAllTools())
+
+		_, err := reg.getPageBySlug(t.Context(), getPageBySlugInputDTO{Slug: "page", Org: "partner"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown org "partner"`)
+	})
+}